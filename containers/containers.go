@@ -34,3 +34,15 @@ func GetSortedValues[T comparable](container Container[T], comparator utils.Comp
 	utils.Sort(values, comparator)
 	return values
 }
+
+// Sort sorts values (in-place) with respect to the given comparator,
+// e.g. the comparator a container was built with to order the result of its Keys() or Values().
+func Sort[T any](values []T, comparator utils.Comparator) {
+	utils.Sort(values, comparator)
+}
+
+// SortStable sorts values (in-place) with respect to the given comparator,
+// keeping the original order of elements the comparator considers equal.
+func SortStable[T any](values []T, comparator utils.Comparator) {
+	utils.SortStable(values, comparator)
+}
@@ -68,3 +68,22 @@ func TestGetSortedValuesStrings(t *testing.T) {
 		}
 	}
 }
+
+func TestSort(t *testing.T) {
+	values := []string{"g", "a", "d", "e", "f", "c", "b"}
+	Sort(values, utils.StringComparator)
+	if actualValue, expectedValue := strings.Join(values, ""), "abcdefg"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	values := []string{"bb", "a", "cc", "b", "aa", "c"}
+	byLength := func(a, b interface{}) int {
+		return utils.IntComparator(len(a.(string)), len(b.(string)))
+	}
+	SortStable(values, byLength)
+	if actualValue, expectedValue := strings.Join(values, ","), "a,b,c,bb,cc,aa"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
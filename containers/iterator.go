@@ -131,3 +131,23 @@ type ReverseIteratorWithKey[TKey, TValue comparable] interface {
 
 	IteratorWithKey[TKey, TValue]
 }
+
+// Collect drains the iterator from its current position and returns the visited values in iteration order.
+// Call Begin() (or use a fresh iterator) to collect all values of the container.
+func Collect[T comparable](iterator IteratorWithIndex[T]) []T {
+	values := []T{}
+	for iterator.Next() {
+		values = append(values, iterator.Value())
+	}
+	return values
+}
+
+// CollectValues drains the iterator from its current position and returns the visited values in iteration order.
+// Call Begin() (or use a fresh iterator) to collect all values of the container.
+func CollectValues[TKey, TValue comparable](iterator IteratorWithKey[TKey, TValue]) []TValue {
+	values := []TValue{}
+	for iterator.Next() {
+		values = append(values, iterator.Value())
+	}
+	return values
+}
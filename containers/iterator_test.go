@@ -0,0 +1,44 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package containers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/a234567894/gods/containers"
+	"github.com/a234567894/gods/lists/arraylist"
+	"github.com/a234567894/gods/maps/treemap"
+	"github.com/a234567894/gods/trees/binaryheap"
+)
+
+func TestCollect(t *testing.T) {
+	list := arraylist.New[string]("a", "b", "c")
+	it := list.Iterator()
+	if actualValue, expectedValue := fmt.Sprint(containers.Collect[string](&it)), "[a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := containers.Collect[string](&it); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+
+	heap := binaryheap.NewWithIntComparator[int]()
+	heap.Push(3, 1, 2)
+	heapIt := heap.Iterator()
+	heapIt.Next()
+	if actualValue, expectedValue := len(containers.Collect[int](&heapIt)), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestCollectValues(t *testing.T) {
+	m := treemap.NewWithIntComparator[int, string]()
+	m.Put(2, "b")
+	m.Put(1, "a")
+	it := m.Iterator()
+	if actualValue, expectedValue := fmt.Sprint(containers.CollectValues[int, string](&it)), "[a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
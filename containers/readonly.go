@@ -0,0 +1,125 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package containers
+
+// ReadableMap is the read subset of the map interface, which all maps implement.
+type ReadableMap[TKey, TValue comparable] interface {
+	Get(key TKey) (value TValue, found bool)
+	Keys() []TKey
+	Values() []TValue
+	Empty() bool
+	Size() int
+	String() string
+}
+
+// ReadableList is the read subset of the list interface, which all lists implement.
+type ReadableList[T comparable] interface {
+	Get(index int) (T, bool)
+	Contains(values ...T) bool
+	Values() []T
+	Empty() bool
+	Size() int
+	String() string
+}
+
+// ReadOnlyMap is a view of a map that only exposes its read operations,
+// so holders of the view can not modify the map. Changes made to the map itself are visible through the view.
+type ReadOnlyMap[TKey, TValue comparable] struct {
+	m ReadableMap[TKey, TValue]
+}
+
+// NewReadOnlyMap instantiates a read-only view of the given map.
+func NewReadOnlyMap[TKey, TValue comparable](m ReadableMap[TKey, TValue]) *ReadOnlyMap[TKey, TValue] {
+	return &ReadOnlyMap[TKey, TValue]{m: m}
+}
+
+// Get searches the element in the map by key and returns its value.
+// Second return parameter is true if key was found, otherwise false.
+func (view *ReadOnlyMap[TKey, TValue]) Get(key TKey) (value TValue, found bool) {
+	return view.m.Get(key)
+}
+
+// Keys returns all keys in the order of the underlying map.
+func (view *ReadOnlyMap[TKey, TValue]) Keys() []TKey {
+	return view.m.Keys()
+}
+
+// Values returns all values in the order of the underlying map.
+func (view *ReadOnlyMap[TKey, TValue]) Values() []TValue {
+	return view.m.Values()
+}
+
+// Empty returns true if map does not contain any elements.
+func (view *ReadOnlyMap[TKey, TValue]) Empty() bool {
+	return view.m.Empty()
+}
+
+// Size returns number of elements in the map.
+func (view *ReadOnlyMap[TKey, TValue]) Size() int {
+	return view.m.Size()
+}
+
+// String returns a string representation of the underlying map.
+func (view *ReadOnlyMap[TKey, TValue]) String() string {
+	return view.m.String()
+}
+
+// Each calls the given function once for each element in the order of the underlying map's keys,
+// passing that element's key and value.
+func (view *ReadOnlyMap[TKey, TValue]) Each(f func(key TKey, value TValue)) {
+	for _, key := range view.m.Keys() {
+		value, _ := view.m.Get(key)
+		f(key, value)
+	}
+}
+
+// ReadOnlyList is a view of a list that only exposes its read operations,
+// so holders of the view can not modify the list. Changes made to the list itself are visible through the view.
+type ReadOnlyList[T comparable] struct {
+	list ReadableList[T]
+}
+
+// NewReadOnlyList instantiates a read-only view of the given list.
+func NewReadOnlyList[T comparable](list ReadableList[T]) *ReadOnlyList[T] {
+	return &ReadOnlyList[T]{list: list}
+}
+
+// Get returns the element at index.
+// Second return parameter is true if index is within bounds of the list, otherwise false.
+func (view *ReadOnlyList[T]) Get(index int) (T, bool) {
+	return view.list.Get(index)
+}
+
+// Contains checks if all given values are in the list.
+func (view *ReadOnlyList[T]) Contains(values ...T) bool {
+	return view.list.Contains(values...)
+}
+
+// Values returns all elements in the list.
+func (view *ReadOnlyList[T]) Values() []T {
+	return view.list.Values()
+}
+
+// Empty returns true if list does not contain any elements.
+func (view *ReadOnlyList[T]) Empty() bool {
+	return view.list.Empty()
+}
+
+// Size returns number of elements within the list.
+func (view *ReadOnlyList[T]) Size() int {
+	return view.list.Size()
+}
+
+// String returns a string representation of the underlying list.
+func (view *ReadOnlyList[T]) String() string {
+	return view.list.String()
+}
+
+// Each calls the given function once for each element, passing that element's index and value.
+func (view *ReadOnlyList[T]) Each(f func(index int, value T)) {
+	for index, value := range view.list.Values() {
+		f(index, value)
+	}
+}
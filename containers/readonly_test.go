@@ -0,0 +1,68 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package containers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/a234567894/gods/containers"
+	"github.com/a234567894/gods/lists/arraylist"
+	"github.com/a234567894/gods/lists/singlylinkedlist"
+	"github.com/a234567894/gods/maps/hashmap"
+	"github.com/a234567894/gods/maps/treemap"
+)
+
+func TestReadOnlyMap(t *testing.T) {
+	m := treemap.NewWithIntComparator[int, string]()
+	m.Put(2, "b")
+	view := containers.NewReadOnlyMap[int, string](m)
+	m.Put(1, "a")
+
+	if actualValue, found := view.Get(1); actualValue != "a" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+	if actualValue, expectedValue := fmt.Sprint(view.Keys(), view.Values(), view.Size(), view.Empty()), "[1 2] [a b] 2 false"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	str := ""
+	view.Each(func(key int, value string) {
+		str += fmt.Sprint(key, value)
+	})
+	if actualValue, expectedValue := str, "1a2b"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := view.String(), m.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue := containers.NewReadOnlyMap[string, int](hashmap.New[string, int]()).Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestReadOnlyList(t *testing.T) {
+	list := arraylist.New[string]("a", "b")
+	view := containers.NewReadOnlyList[string](list)
+	list.Add("c")
+
+	if actualValue, found := view.Get(2); actualValue != "c" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "c")
+	}
+	if actualValue, expectedValue := fmt.Sprint(view.Values(), view.Size(), view.Contains("a", "c"), view.Contains("d")), "[a b c] 3 true false"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	str := ""
+	view.Each(func(index int, value string) {
+		str += fmt.Sprint(index, value)
+	})
+	if actualValue, expectedValue := str, "0a1b2c"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue := containers.NewReadOnlyList[int](singlylinkedlist.New[int]()).Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
@@ -4,6 +4,8 @@
 
 package containers
 
+import "io"
+
 // JSONSerializer provides JSON serialization
 type JSONSerializer interface {
 	// ToJSON outputs the JSON representation of containers's elements.
@@ -19,3 +21,15 @@ type JSONDeserializer interface {
 	// UnmarshalJSON @implements json.Unmarshaler
 	UnmarshalJSON([]byte) error
 }
+
+// JSONStreamSerializer provides JSON serialization to a stream, without building the whole representation in memory
+type JSONStreamSerializer interface {
+	// WriteJSON writes the JSON representation of containers's elements to the writer.
+	WriteJSON(w io.Writer) error
+}
+
+// JSONStreamDeserializer provides JSON deserialization from a stream, without reading the whole representation into memory
+type JSONStreamDeserializer interface {
+	// ReadJSON populates containers's elements from the JSON representation read from the reader.
+	ReadJSON(r io.Reader) error
+}
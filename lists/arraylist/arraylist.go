@@ -131,6 +131,16 @@ func (list *List[T]) Clear() {
 	list.elements = []T{}
 }
 
+// ClearKeepCapacity removes all elements from the list but retains the allocated capacity,
+// so that refilling the list to a similar size does not need to grow it again.
+func (list *List[T]) ClearKeepCapacity() {
+	var zero T
+	for i := 0; i < list.size; i++ {
+		list.elements[i] = zero
+	}
+	list.size = 0
+}
+
 // Sort sorts values (in-place) using.
 func (list *List[T]) Sort(comparator utils.Comparator) {
 	if len(list.elements) < 2 {
@@ -157,6 +157,25 @@ func TestListClear(t *testing.T) {
 	}
 }
 
+func TestListClearKeepCapacity(t *testing.T) {
+	list := New[string]()
+	list.Add("a", "b", "c")
+	list.ClearKeepCapacity()
+	if actualValue := list.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if allocs := testing.AllocsPerRun(10, func() {
+		list.Add("d", "e", "f")
+		list.ClearKeepCapacity()
+	}); allocs != 0 {
+		t.Errorf("Got %v expected %v", allocs, 0)
+	}
+	list.Add("x")
+	if actualValue, expectedValue := strings.Join(list.Values(), ","), "x"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestListContains(t *testing.T) {
 	list := New[string]()
 	list.Add("a")
@@ -6,6 +6,11 @@
 //
 // In computer science, a list or sequence is an abstract data type that represents an ordered sequence of values, where the same value may occur more than once. An instance of a list is a computer representation of the mathematical concept of a finite sequence; the (potentially) infinite analog of a list is a stream.  Lists are a basic example of containers, as they contain other values. If the same value occurs multiple times, each occurrence is considered a distinct item.
 //
+// Stack and queue adapters over these lists live in their own packages, e.g. stacks/linkedliststack and
+// queues/linkedlistqueue (backed by a singly-linked list) or stacks/arraystack and queues/arrayqueue.
+// Their Pop, Dequeue and Peek return the zero value and false when empty, and none of them has a size limit;
+// queues/circularbuffer is the bounded queue that overwrites its oldest element when full.
+//
 // Reference: https://en.wikipedia.org/wiki/List_%28abstract_data_type%29
 package lists
 
@@ -36,6 +36,13 @@ func New[TKey, TValue comparable]() *Map[TKey, TValue] {
 	return &Map[TKey, TValue]{*hashmap.New[TKey, TValue](), *hashmap.New[TValue, TKey]()}
 }
 
+// NewFromMap instantiates a bidirectional map holding the given entries (see PutAll).
+func NewFromMap[TKey, TValue comparable](entries map[TKey]TValue) *Map[TKey, TValue] {
+	m := New[TKey, TValue]()
+	m.PutAll(entries)
+	return m
+}
+
 // Put inserts element into the map.
 func (m *Map[TKey, TValue]) Put(key TKey, value TValue) {
 	if valueByKey, ok := m.forwardMap.Get(key); ok {
@@ -48,6 +55,15 @@ func (m *Map[TKey, TValue]) Put(key TKey, value TValue) {
 	m.inverseMap.Put(value, key)
 }
 
+// PutAll inserts all given entries into the map as if by calling Put for each of them.
+// If several keys map to the same value, each insertion evicts the previous pair holding that value,
+// so exactly one of those keys survives; which one is unspecified as Go's map iteration order is random.
+func (m *Map[TKey, TValue]) PutAll(entries map[TKey]TValue) {
+	for key, value := range entries {
+		m.Put(key, value)
+	}
+}
+
 // Get searches the element in the map by key and returns its value or nil if key is not found in map.
 // Second return parameter is true if key was found, otherwise false.
 func (m *Map[TKey, TValue]) Get(key TKey) (value TValue, found bool) {
@@ -68,6 +84,18 @@ func (m *Map[TKey, TValue]) Remove(key TKey) {
 	}
 }
 
+// RemoveByValue removes the element from the map by value.
+// Returns true if an element was removed, otherwise false.
+func (m *Map[TKey, TValue]) RemoveByValue(value TValue) bool {
+	key, found := m.inverseMap.Get(value)
+	if !found {
+		return false
+	}
+	m.inverseMap.Remove(value)
+	m.forwardMap.Remove(key)
+	return true
+}
+
 // Empty returns true if map does not contain any elements
 func (m *Map[TKey, TValue]) Empty() bool {
 	return m.Size() == 0
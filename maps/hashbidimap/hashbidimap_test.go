@@ -152,6 +152,113 @@ func TestMapGetKey(t *testing.T) {
 	}
 }
 
+func assertBijection(t *testing.T, m *Map[int, int]) {
+	t.Helper()
+	if forward, inverse := m.forwardMap.Size(), m.inverseMap.Size(); forward != inverse {
+		t.Errorf("Got %v expected %v", inverse, forward)
+	}
+	for _, key := range m.Keys() {
+		value, _ := m.Get(key)
+		if actualValue, found := m.GetKey(value); actualValue != key || !found {
+			t.Errorf("Got %v expected %v", actualValue, key)
+		}
+	}
+}
+
+func TestMapPutOverlappingKeysAndValues(t *testing.T) {
+	m := New[int, int]()
+	m.Put(1, 2)
+	m.Put(2, 1)
+	assertBijection(t, m)
+
+	// new key equals an existing value
+	m.Put(2, 2)
+	assertBijection(t, m)
+	if actualValue, expectedValue := fmt.Sprint(m.Size(), m.forwardMap.ToMap()), "1 map[2:2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// new value equals an existing key
+	m.Put(3, 1)
+	m.Put(1, 3)
+	assertBijection(t, m)
+	if actualValue, expectedValue := fmt.Sprint(m.Size(), m.forwardMap.ToMap()), "3 map[1:3 2:2 3:1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for i := 0; i < 1000; i++ {
+		m.Put((i*7)%13, (i*11)%17)
+		assertBijection(t, m)
+	}
+}
+
+func TestMapPutAll(t *testing.T) {
+	m := NewFromMap(map[string]int{"a": 1, "b": 2})
+	if actualValue, expectedValue := m.Keys(), []string{"a", "b"}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// existing pairs are evicted consistently on both sides
+	m.PutAll(map[string]int{"c": 1, "b": 3})
+	if actualValue, expectedValue := m.Keys(), []string{"b", "c"}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Values(), []int{1, 3}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.GetKey(1); actualValue != "c" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "c")
+	}
+	if _, found := m.GetKey(2); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+
+	// two keys mapping to the same value within one bulk load
+	m = NewFromMap(map[string]int{"x": 9, "y": 9, "z": 8})
+	if actualValue := m.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	key, found := m.GetKey(9)
+	if !found || (key != "x" && key != "y") {
+		t.Errorf("Got %v expected %v", key, "x or y")
+	}
+	if actualValue, found := m.Get(key); actualValue != 9 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 9)
+	}
+	if actualValue, expectedValue := m.Values(), []int{8, 9}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapRemoveByValue(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	if actualValue := m.RemoveByValue(2); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := m.RemoveByValue(2); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := m.RemoveByValue(4); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue, expectedValue := m.Keys(), []string{"a", "c"}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Values(), []int{1, 3}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, found := m.Get("b"); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+	if actualValue := m.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+}
+
 func TestMapSerialization(t *testing.T) {
 	m := New[string, float32]()
 	m.Put("a", 1.0)
@@ -193,6 +300,42 @@ func TestMapSerialization(t *testing.T) {
 	}
 }
 
+func TestMapSerializationRoundTrip(t *testing.T) {
+	original := New[int, string]()
+	for i := 0; i < 1000; i++ {
+		original.Put(i, fmt.Sprintf("id-%d", i))
+	}
+	serialized, err := original.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	deserialized := New[int, string]()
+	deserialized.Put(-1, "stale")
+	if err := deserialized.FromJSON(serialized); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := deserialized.Size(), 1000; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 0; i < 1000; i++ {
+		if actualValue, found := deserialized.Get(i); !found || actualValue != fmt.Sprintf("id-%d", i) {
+			t.Errorf("Got %v expected %v", actualValue, fmt.Sprintf("id-%d", i))
+		}
+		if actualValue, found := deserialized.GetKey(fmt.Sprintf("id-%d", i)); !found || actualValue != i {
+			t.Errorf("Got %v expected %v", actualValue, i)
+		}
+	}
+
+	reserialized, err := deserialized.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(reserialized), string(serialized); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestMapString(t *testing.T) {
 	c := New[string, int]()
 	c.Put("a", 1)
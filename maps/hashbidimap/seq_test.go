@@ -0,0 +1,39 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package hashbidimap
+
+import "testing"
+
+func TestMapIter(t *testing.T) {
+	m := New[string, int]()
+	for range m.Iter() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	seen := New[string, int]()
+	for key, value := range m.Iter() {
+		seen.Put(key, value)
+	}
+	if actualValue, expectedValue := seen.String(), m.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := seen.GetKey(2); actualValue != "b" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "b")
+	}
+
+	count := 0
+	for range m.Iter() {
+		count++
+		break
+	}
+	if actualValue, expectedValue := count, 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
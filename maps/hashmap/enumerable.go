@@ -0,0 +1,95 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hashmap
+
+import "github.com/a234567894/gods/containers"
+
+// Assert Enumerable implementation
+var _ containers.EnumerableWithKey[string, string] = (*Map[string, string])(nil)
+
+// Each calls the given function once for each element (random order), passing that element's key and value.
+func (m *Map[TKey, TValue]) Each(f func(key TKey, value TValue)) {
+	for key, value := range m.m {
+		f(key, value)
+	}
+}
+
+// EachWhile calls the given function for each element (random order), passing that element's key and value,
+// until the function returns false.
+func (m *Map[TKey, TValue]) EachWhile(f func(key TKey, value TValue) bool) {
+	for key, value := range m.m {
+		if !f(key, value) {
+			return
+		}
+	}
+}
+
+// Map invokes the given function once for each element and returns a new hash map
+// containing the values returned by the given function as key/value pairs.
+// If the function returns the same key for several elements, one of their values is kept at random.
+func (m *Map[TKey, TValue]) Map(f func(key1 TKey, value1 TValue) (TKey, TValue)) *Map[TKey, TValue] {
+	newMap := &Map[TKey, TValue]{m: make(map[TKey]TValue, len(m.m))}
+	for key1, value1 := range m.m {
+		key2, value2 := f(key1, value1)
+		newMap.m[key2] = value2
+	}
+	return newMap
+}
+
+// Select returns a new hash map containing all elements for which the given function returns a true value.
+func (m *Map[TKey, TValue]) Select(f func(key TKey, value TValue) bool) *Map[TKey, TValue] {
+	newMap := New[TKey, TValue]()
+	for key, value := range m.m {
+		if f(key, value) {
+			newMap.m[key] = value
+		}
+	}
+	return newMap
+}
+
+// Any passes each element of the container to the given function and
+// returns true if the function ever returns true for any element.
+func (m *Map[TKey, TValue]) Any(f func(key TKey, value TValue) bool) bool {
+	for key, value := range m.m {
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// All passes each element of the container to the given function and
+// returns true if the function returns true for all elements.
+func (m *Map[TKey, TValue]) All(f func(key TKey, value TValue) bool) bool {
+	for key, value := range m.m {
+		if !f(key, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find passes each element of the container to the given function and returns
+// the first (key,value) for which the function is true or nil,nil otherwise if no element
+// matches the criteria. As the map is unordered, any matching element may be returned.
+func (m *Map[TKey, TValue]) Find(f func(key TKey, value TValue) bool) (TKey, TValue) {
+	for key, value := range m.m {
+		if f(key, value) {
+			return key, value
+		}
+	}
+	return *new(TKey), *new(TValue)
+}
+
+// Reduce folds all elements of the map (random order) into a single value, starting with the initial
+// accumulator and replacing it by the result of the given function for each element.
+// The function should be insensitive to the order of the elements, e.g. a sum or a count.
+func Reduce[TKey, TValue comparable, TAcc any](m *Map[TKey, TValue], initial TAcc, f func(acc TAcc, key TKey, value TValue) TAcc) TAcc {
+	acc := initial
+	for key, value := range m.m {
+		acc = f(acc, key, value)
+	}
+	return acc
+}
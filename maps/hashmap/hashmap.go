@@ -15,6 +15,7 @@ import (
 	"fmt"
 
 	"github.com/a234567894/gods/maps"
+	"github.com/a234567894/gods/utils"
 )
 
 // Assert Map implementation
@@ -30,6 +31,29 @@ func New[TKey, TValue comparable]() *Map[TKey, TValue] {
 	return &Map[TKey, TValue]{m: make(map[TKey]TValue)}
 }
 
+// FromSlices instantiates a hash map from two parallel slices of keys and values, i.e. keys[i] maps to values[i].
+// If a key is repeated, the value paired with its last occurrence wins.
+// Returns an error if the slices differ in length.
+func FromSlices[TKey, TValue comparable](keys []TKey, values []TValue) (*Map[TKey, TValue], error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("keys and values differ in length: %d != %d", len(keys), len(values))
+	}
+	m := &Map[TKey, TValue]{m: make(map[TKey]TValue, len(keys))}
+	for i, key := range keys {
+		m.m[key] = values[i]
+	}
+	return m, nil
+}
+
+// FromMap instantiates a hash map holding a copy of the given native map.
+func FromMap[TKey, TValue comparable](entries map[TKey]TValue) *Map[TKey, TValue] {
+	m := &Map[TKey, TValue]{m: make(map[TKey]TValue, len(entries))}
+	for key, value := range entries {
+		m.m[key] = value
+	}
+	return m
+}
+
 // Put inserts element into the map.
 func (m *Map[TKey, TValue]) Put(key TKey, value TValue) {
 	m.m[key] = value
@@ -47,6 +71,15 @@ func (m *Map[TKey, TValue]) Remove(key TKey) {
 	delete(m.m, key)
 }
 
+// Pop removes the element from the map by key and returns its value.
+// Second return parameter is true if key was found, otherwise false.
+func (m *Map[TKey, TValue]) Pop(key TKey) (value TValue, found bool) {
+	if value, found = m.m[key]; found {
+		delete(m.m, key)
+	}
+	return value, found
+}
+
 // Empty returns true if map does not contain any elements
 func (m *Map[TKey, TValue]) Empty() bool {
 	return m.Size() == 0
@@ -58,6 +91,7 @@ func (m *Map[TKey, TValue]) Size() int {
 }
 
 // Keys returns all keys (random order).
+// The order is left unspecified for performance, use KeysSorted for a deterministic order.
 func (m *Map[TKey, TValue]) Keys() []TKey {
 	keys := make([]TKey, m.Size())
 	count := 0
@@ -68,6 +102,13 @@ func (m *Map[TKey, TValue]) Keys() []TKey {
 	return keys
 }
 
+// KeysSorted returns all keys sorted by the given comparator.
+func (m *Map[TKey, TValue]) KeysSorted(comparator utils.Comparator) []TKey {
+	keys := m.Keys()
+	utils.Sort(keys, comparator)
+	return keys
+}
+
 // Values returns all values (random order).
 func (m *Map[TKey, TValue]) Values() []TValue {
 	values := make([]TValue, m.Size())
@@ -79,11 +120,123 @@ func (m *Map[TKey, TValue]) Values() []TValue {
 	return values
 }
 
+// ToMap returns a shallow copy of the map as a native map, so modifying it does not affect the map.
+func (m *Map[TKey, TValue]) ToMap() map[TKey]TValue {
+	entries := make(map[TKey]TValue, len(m.m))
+	for key, value := range m.m {
+		entries[key] = value
+	}
+	return entries
+}
+
 // Clear removes all elements from the map.
 func (m *Map[TKey, TValue]) Clear() {
 	m.m = make(map[TKey]TValue)
 }
 
+// ClearKeepCapacity removes all elements from the map but retains the memory allocated for them,
+// so that refilling the map to a similar size does not need to grow it again.
+func (m *Map[TKey, TValue]) ClearKeepCapacity() {
+	for key := range m.m {
+		delete(m.m, key)
+	}
+}
+
+// Compact rebuilds the underlying native map at its current size.
+// Go maps never shrink, so this releases the memory retained by a map that once held many more elements.
+func (m *Map[TKey, TValue]) Compact() {
+	compacted := make(map[TKey]TValue, len(m.m))
+	for key, value := range m.m {
+		compacted[key] = value
+	}
+	m.m = compacted
+}
+
+// RemapKeys replaces every key in the map by the key returned by the given function.
+// If several entries are mapped to the same key, their values are merged by the given resolve function,
+// which receives the value already stored under the new key and the incoming one (in random order).
+// Returns the number of collisions that had to be resolved.
+func (m *Map[TKey, TValue]) RemapKeys(f func(TKey) TKey, resolve func(existing, incoming TValue) TValue) int {
+	remapped := make(map[TKey]TValue, len(m.m))
+	collisions := 0
+	for key, value := range m.m {
+		newKey := f(key)
+		if existing, found := remapped[newKey]; found {
+			value = resolve(existing, value)
+			collisions++
+		}
+		remapped[newKey] = value
+	}
+	m.m = remapped
+	return collisions
+}
+
+// MergeReport merges all entries of the other map into the map.
+// If a key is present in both maps, the stored value is replaced by the one returned by the given resolve function,
+// which receives the key, the existing value and the incoming one.
+// Returns the number of newly inserted keys and the number of resolved conflicts.
+func (m *Map[TKey, TValue]) MergeReport(other *Map[TKey, TValue], resolve func(key TKey, existing, incoming TValue) TValue) (inserted, conflicted int) {
+	for key, value := range other.m {
+		if existing, found := m.m[key]; found {
+			value = resolve(key, existing, value)
+			conflicted++
+		} else {
+			inserted++
+		}
+		m.m[key] = value
+	}
+	return
+}
+
+// IsSubmapOf returns true if every key of the map is contained in the other map with an equal value.
+func (m *Map[TKey, TValue]) IsSubmapOf(other *Map[TKey, TValue]) bool {
+	if m.Size() > other.Size() {
+		return false
+	}
+	for key, value := range m.m {
+		if otherValue, found := other.m[key]; !found || otherValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Invert returns a new map from each value to its key.
+// If several keys share a value, only one of them is kept, and which one is unspecified since the map is unordered;
+// use InvertMulti to keep all of them.
+func (m *Map[TKey, TValue]) Invert() *Map[TValue, TKey] {
+	inverted := &Map[TValue, TKey]{m: make(map[TValue]TKey, len(m.m))}
+	for key, value := range m.m {
+		inverted.m[value] = key
+	}
+	return inverted
+}
+
+// InvertMulti returns a native map from each value to all keys that map to it (random order).
+func (m *Map[TKey, TValue]) InvertMulti() map[TValue][]TKey {
+	inverted := make(map[TValue][]TKey)
+	for key, value := range m.m {
+		inverted[value] = append(inverted[value], key)
+	}
+	return inverted
+}
+
+// ValueStats computes the minimum, maximum and sum of the values of a map with int values in a single pass,
+// along with the number of values. All results are zero for an empty map.
+func ValueStats[TKey comparable](m *Map[TKey, int]) (min, max, sum int, count int) {
+	for _, value := range m.m {
+		if count == 0 || value < min {
+			min = value
+		}
+		if count == 0 || value > max {
+			max = value
+		}
+		sum += value
+		count++
+	}
+	return
+}
+
 // String returns a string representation of container
 func (m *Map[TKey, TValue]) String() string {
 	str := "HashMap\n"
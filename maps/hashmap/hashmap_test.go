@@ -8,7 +8,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/a234567894/gods/maps"
+	"github.com/a234567894/gods/utils"
 )
 
 func TestMapPut(t *testing.T) {
@@ -53,6 +57,65 @@ func TestMapPut(t *testing.T) {
 	}
 }
 
+func TestMapPop(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 0)
+	if value, found := m.Pop("a"); value != 1 || !found {
+		t.Errorf("Got %v %v expected %v %v", value, found, 1, true)
+	}
+	if value, found := m.Pop("a"); value != 0 || found {
+		t.Errorf("Got %v %v expected %v %v", value, found, 0, false)
+	}
+	if value, found := m.Pop("b"); value != 0 || !found {
+		t.Errorf("Got %v %v expected %v %v", value, found, 0, true)
+	}
+	if actualValue, expectedValue := m.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapFromSlices(t *testing.T) {
+	m, err := FromSlices([]string{"a", "b", "c"}, []int{1, 2, 3})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := m.Keys(), []string{"a", "b", "c"}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get("b"); actualValue != 2 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+
+	// mismatched lengths
+	m, err = FromSlices([]string{"a", "b"}, []int{1})
+	if err == nil || m != nil {
+		t.Errorf("Expected error on mismatched lengths")
+	}
+
+	// duplicate keys, last wins
+	m, err = FromSlices([]string{"a", "b", "a"}, []int{1, 2, 3})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue := m.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, found := m.Get("a"); actualValue != 3 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	// empty
+	m, err = FromSlices([]string{}, []int{})
+	if err != nil || !m.Empty() {
+		t.Errorf("Got %v %v expected empty map", m, err)
+	}
+	m.Put("a", 1)
+	if actualValue, found := m.Get("a"); actualValue != 1 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
 func TestMapRemove(t *testing.T) {
 	m := New[int, string]()
 	m.Put(5, "e")
@@ -120,6 +183,133 @@ func TestMapRemove(t *testing.T) {
 	}
 }
 
+func TestMapClearKeepCapacityAndCompact(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i)
+	}
+	m.ClearKeepCapacity()
+	if actualValue, expectedValue := m.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i)
+	}
+	for i := 0; i < 998; i++ {
+		m.Remove(i)
+	}
+	m.Compact()
+	if actualValue, expectedValue := m.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get(999); actualValue != 999 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 999)
+	}
+	m.Put(1, 1)
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapRemapKeys(t *testing.T) {
+	m := New[int, int]()
+	m.Put(1, 10)
+	m.Put(2, 20)
+	m.Put(3, 30)
+
+	// injective mapping, no collisions
+	collisions := m.RemapKeys(func(key int) int { return key * 100 }, func(existing, incoming int) int {
+		t.Errorf("Should not resolve without collisions")
+		return existing
+	})
+	if actualValue, expectedValue := collisions, 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Keys(), []int{100, 200, 300}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get(200); actualValue != 20 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 20)
+	}
+
+	// collapsing mapping, collisions resolved by summing
+	m.Put(400, 40)
+	collisions = m.RemapKeys(func(key int) int { return key % 200 }, func(existing, incoming int) int {
+		return existing + incoming
+	})
+	if actualValue, expectedValue := collisions, 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get(0); actualValue != 60 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 60)
+	}
+	if actualValue, found := m.Get(100); actualValue != 40 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 40)
+	}
+}
+
+func TestMapIsSubmapOf(t *testing.T) {
+	m := New[string, int]()
+	other := New[string, int]()
+	if actualValue := m.IsSubmapOf(other); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	other.Put("a", 1)
+	other.Put("b", 2)
+	other.Put("c", 3)
+
+	// strict submap
+	if actualValue := m.IsSubmapOf(other); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := other.IsSubmapOf(m); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	// equal maps
+	m.Put("c", 3)
+	if actualValue := m.IsSubmapOf(other); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := other.IsSubmapOf(m); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	// differing value
+	m.Remove("c")
+	m.Put("b", 20)
+	if actualValue := m.IsSubmapOf(other); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestMapValueStats(t *testing.T) {
+	m := New[string, int]()
+	if min, max, sum, count := ValueStats(m); min != 0 || max != 0 || sum != 0 || count != 0 {
+		t.Errorf("Got %v %v %v %v expected %v %v %v %v", min, max, sum, count, 0, 0, 0, 0)
+	}
+
+	m.Put("a", 5)
+	m.Put("b", -7)
+	m.Put("c", 12)
+	m.Put("d", 0)
+	if min, max, sum, count := ValueStats(m); min != -7 || max != 12 || sum != 10 || count != 4 {
+		t.Errorf("Got %v %v %v %v expected %v %v %v %v", min, max, sum, count, -7, 12, 10, 4)
+	}
+
+	m.Clear()
+	m.Put("a", -3)
+	if min, max, sum, count := ValueStats(m); min != -3 || max != -3 || sum != -3 || count != 1 {
+		t.Errorf("Got %v %v %v %v expected %v %v %v %v", min, max, sum, count, -3, -3, -3, 1)
+	}
+}
+
 func TestMapSerialization(t *testing.T) {
 	m := New[string, float32]()
 	m.Put("a", 1.0)
@@ -188,6 +378,361 @@ func sameElements[T comparable](a []T, b []T) bool {
 	return true
 }
 
+func TestMapKeysSorted(t *testing.T) {
+	m := New[string, int]()
+	if actualValue := m.KeysSorted(utils.StringComparator); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("d", 4)
+	m.Put("b", 2)
+	if actualValue, expectedValue := fmt.Sprint(m.KeysSorted(utils.StringComparator)), "[a b c d]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.KeysSorted(utils.Reverse(utils.StringComparator))), "[d c b a]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapToMapAndFromMap(t *testing.T) {
+	entries := map[string]int{"a": 1, "b": 2}
+	m := FromMap(entries)
+	entries["c"] = 3
+	if actualValue, expectedValue := m.String(), "HashMap\nmap[a:1 b:2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	native := m.ToMap()
+	if actualValue, expectedValue := fmt.Sprint(native), "map[a:1 b:2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	native["a"] = 10
+	delete(native, "b")
+	if actualValue, expectedValue := m.String(), "HashMap\nmap[a:1 b:2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := New[string, int]().ToMap(); actualValue == nil || len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "map[]")
+	}
+}
+
+func TestMapInvert(t *testing.T) {
+	m := FromMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	if actualValue, expectedValue := m.Invert().String(), "HashMap\nmap[1:a 2:b 3:c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	m.Put("d", 1)
+	inverted := m.Invert()
+	if actualValue, expectedValue := inverted.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, _ := inverted.Get(1); actualValue != "a" && actualValue != "d" {
+		t.Errorf("Got %v expected %v", actualValue, "a or d")
+	}
+	if actualValue := New[string, int]().Invert(); !actualValue.Empty() {
+		t.Errorf("Got %v expected %v", actualValue, "empty map")
+	}
+}
+
+func TestMapInvertMulti(t *testing.T) {
+	m := FromMap(map[string]int{"a": 1, "b": 2, "c": 1, "d": 1})
+	inverted := m.InvertMulti()
+	if actualValue, expectedValue := len(inverted), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	keys := inverted[1]
+	utils.Sort(keys, utils.StringComparator)
+	if actualValue, expectedValue := fmt.Sprint(keys, inverted[2]), "[a c d] [b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapMergeReport(t *testing.T) {
+	sum := func(key string, existing, incoming int) int { return existing + incoming }
+
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	other := New[string, int]()
+	other.Put("c", 3)
+	other.Put("d", 4)
+
+	inserted, conflicted := m.MergeReport(other, sum)
+	if actualValue, expectedValue := fmt.Sprint(inserted, conflicted), "2 0"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.String(), "HashMap\nmap[a:1 b:2 c:3 d:4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	other.Clear()
+	other.Put("a", 10)
+	other.Put("d", 40)
+	other.Put("e", 50)
+	inserted, conflicted = m.MergeReport(other, sum)
+	if actualValue, expectedValue := fmt.Sprint(inserted, conflicted), "1 2"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.String(), "HashMap\nmap[a:11 b:2 c:3 d:44 e:50]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := other.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIterator(t *testing.T) {
+	m := New[string, int]()
+	if it := m.Iterator(); it.Next() || it.Prev() || it.First() || it.Last() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+	it := m.Iterator()
+	seen := New[string, int]()
+	for it.Next() {
+		seen.Put(it.Key(), it.Value())
+	}
+	if actualValue, expectedValue := seen.String(), m.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	count := 0
+	for it.Prev() {
+		count++
+	}
+	if actualValue, expectedValue := count, 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if !it.NextTo(func(key string, value int) bool { return value == 2 }) || it.Key() != "b" {
+		t.Errorf("Got %v expected %v", it.Key(), "b")
+	}
+
+	// elements are snapshotted, including the values of updated and removed keys
+	it.Begin()
+	m.Put("d", 4)
+	m.Put("a", 10)
+	m.Remove("b")
+	seen.Clear()
+	for it.Next() {
+		seen.Put(it.Key(), it.Value())
+	}
+	if actualValue, expectedValue := seen.String(), "HashMap\nmap[a:1 b:2 c:3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIteratorWithKey(t *testing.T) {
+	dump := func(m maps.IteratorMap[string, int]) int {
+		sum := 0
+		for it := m.IteratorWithKey(); it.Next(); {
+			sum += it.Value()
+		}
+		return sum
+	}
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	if actualValue, expectedValue := dump(m), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapEnumerable(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	sum := 0
+	m.Each(func(key string, value int) {
+		sum += value
+	})
+	if actualValue, expectedValue := sum, 6; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	mapped := m.Map(func(key string, value int) (string, int) {
+		return key + key, value * value
+	})
+	if actualValue, expectedValue := mapped.String(), "HashMap\nmap[aa:1 bb:4 cc:9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	selected := m.Select(func(key string, value int) bool {
+		return value >= 2
+	})
+	if actualValue, expectedValue := selected.String(), "HashMap\nmap[b:2 c:3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	selected.Put("d", 4)
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue := m.Any(func(key string, value int) bool { return value > 2 }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := m.All(func(key string, value int) bool { return value > 2 }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if key, value := m.Find(func(key string, value int) bool { return value == 2 }); key != "b" || value != 2 {
+		t.Errorf("Got %v %v expected %v %v", key, value, "b", 2)
+	}
+	if key, value := m.Find(func(key string, value int) bool { return value > 3 }); key != "" || value != 0 {
+		t.Errorf("Got %v %v expected %v %v", key, value, "", 0)
+	}
+
+	keyLength := Reduce(m, 0.5, func(acc float64, key string, value int) float64 {
+		return acc + float64(len(key)*value)
+	})
+	if actualValue, expectedValue := keyLength, 6.5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := Reduce(New[string, int](), 7, func(acc int, key string, value int) int { return acc + value }), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapEachWhile(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	count := 0
+	m.EachWhile(func(key int, value int) bool {
+		count++
+		return count < 10
+	})
+	if actualValue, expectedValue := count, 10; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	count = 0
+	m.EachWhile(func(key int, value int) bool {
+		count++
+		return true
+	})
+	if actualValue, expectedValue := count, 100; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSyncMapConcurrent(t *testing.T) {
+	m := NewSync[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Put(g*100+i, i)
+				m.Get(i)
+				m.Size()
+				if i%2 == 1 {
+					m.Remove(g*100 + i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if actualValue, expectedValue := m.Size(), 400; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := len(m.Keys()), 400; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get(102); actualValue != 2 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+
+	// Each works on a snapshot, so the callback may mutate the map
+	count := 0
+	m.Each(func(key, value int) {
+		m.Remove(key)
+		count++
+	})
+	if actualValue, expectedValue := count, 400; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := m.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	m.Put(1, 1)
+	if actualValue, expectedValue := m.String(), "HashMap\nmap[1:1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	m.Clear()
+	if actualValue := m.Values(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+}
+
+func TestSyncMapIterator(t *testing.T) {
+	m := NewSync[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	it := m.Iterator()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			m.Remove(i)
+			m.Put(i+100, i)
+		}
+	}()
+	count, sum := 0, 0
+	for it.Next() {
+		if actualValue, expectedValue := it.Value(), it.Key(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count++
+		sum += it.Value()
+	}
+	wg.Wait()
+
+	if actualValue, expectedValue := count, 100; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := sum, 4950; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get(150); actualValue != 50 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 50)
+	}
+}
+
+func TestSyncMapPop(t *testing.T) {
+	m := NewSync[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i)
+	}
+	var wg sync.WaitGroup
+	popped := make([]int, 4)
+	for g := range popped {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if _, found := m.Pop(i); found {
+					popped[g]++
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if actualValue, expectedValue := popped[0]+popped[1]+popped[2]+popped[3], 1000; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func benchmarkGet(b *testing.B, m *Map[int, struct{}], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -0,0 +1,131 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hashmap
+
+import (
+	"github.com/a234567894/gods/containers"
+	"github.com/a234567894/gods/maps"
+)
+
+// Assert Iterator implementation
+var _ containers.ReverseIteratorWithKey[string, string] = (*Iterator[string, string])(nil)
+
+// Assert IteratorMap implementation
+var _ maps.IteratorMap[string, string] = (*Map[string, string])(nil)
+
+// Iterator holding the iterator's state
+type Iterator[TKey, TValue comparable] struct {
+	keys   []TKey
+	values []TValue
+	index  int
+}
+
+// Iterator returns a stateful iterator whose elements are key/value pairs (random order).
+// The elements are snapshotted when the iterator is created, so the iterator never accesses the map itself:
+// elements added afterwards are not visited, and removed or updated ones are still visited with their old values.
+func (m *Map[TKey, TValue]) Iterator() Iterator[TKey, TValue] {
+	keys := make([]TKey, 0, len(m.m))
+	values := make([]TValue, 0, len(m.m))
+	for key, value := range m.m {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return Iterator[TKey, TValue]{keys: keys, values: values, index: -1}
+}
+
+// IteratorWithKey returns a stateful iterator whose elements are key/value pairs (random order).
+func (m *Map[TKey, TValue]) IteratorWithKey() containers.IteratorWithKey[TKey, TValue] {
+	iterator := m.Iterator()
+	return &iterator
+}
+
+// Next moves the iterator to the next element and returns true if there was a next element in the container.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
+// Modifies the state of the iterator.
+func (iterator *Iterator[TKey, TValue]) Next() bool {
+	if iterator.index < len(iterator.keys) {
+		iterator.index++
+	}
+	return iterator.index < len(iterator.keys)
+}
+
+// Prev moves the iterator to the previous element and returns true if there was a previous element in the container.
+// If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[TKey, TValue]) Prev() bool {
+	if iterator.index >= 0 {
+		iterator.index--
+	}
+	return iterator.index >= 0
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[TKey, TValue]) Value() TValue {
+	return iterator.values[iterator.index]
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *Iterator[TKey, TValue]) Key() TKey {
+	return iterator.keys[iterator.index]
+}
+
+// Begin resets the iterator to its initial state (one-before-first)
+// Call Next() to fetch the first element if any.
+func (iterator *Iterator[TKey, TValue]) Begin() {
+	iterator.index = -1
+}
+
+// End moves the iterator past the last element (one-past-the-end).
+// Call Prev() to fetch the last element if any.
+func (iterator *Iterator[TKey, TValue]) End() {
+	iterator.index = len(iterator.keys)
+}
+
+// First moves the iterator to the first element and returns true if there was a first element in the container.
+// If First() returns true, then first element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator
+func (iterator *Iterator[TKey, TValue]) First() bool {
+	iterator.Begin()
+	return iterator.Next()
+}
+
+// Last moves the iterator to the last element and returns true if there was a last element in the container.
+// If Last() returns true, then last element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[TKey, TValue]) Last() bool {
+	iterator.End()
+	return iterator.Prev()
+}
+
+// NextTo moves the iterator to the next element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If NextTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[TKey, TValue]) NextTo(f func(key TKey, value TValue) bool) bool {
+	for iterator.Next() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrevTo moves the iterator to the previous element from current position that satisfies the condition given by the
+// passed function, and returns true if there was a next element in the container.
+// If PrevTo() returns true, then next element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator[TKey, TValue]) PrevTo(f func(key TKey, value TValue) bool) bool {
+	for iterator.Prev() {
+		key, value := iterator.Key(), iterator.Value()
+		if f(key, value) {
+			return true
+		}
+	}
+	return false
+}
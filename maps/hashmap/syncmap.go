@@ -0,0 +1,128 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hashmap
+
+import (
+	"sync"
+
+	"github.com/a234567894/gods/maps"
+)
+
+// Assert Map implementation
+var _ maps.Map[string, string] = (*SyncMap[string, string])(nil)
+
+// SyncMap is a hash map that is safe for concurrent use by multiple goroutines.
+//
+// Read operations take a read lock and mutations take the write lock of the underlying map.
+// Every single operation is atomic, but compound operations (e.g. Get followed by Put) are not,
+// and still need external coordination.
+type SyncMap[TKey, TValue comparable] struct {
+	mutex sync.RWMutex
+	m     *Map[TKey, TValue]
+}
+
+// NewSync instantiates a hash map that is safe for concurrent use.
+func NewSync[TKey, TValue comparable]() *SyncMap[TKey, TValue] {
+	return &SyncMap[TKey, TValue]{m: New[TKey, TValue]()}
+}
+
+// Put inserts element into the map.
+func (m *SyncMap[TKey, TValue]) Put(key TKey, value TValue) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.m.Put(key, value)
+}
+
+// Get searches the element in the map by key and returns its value or nil if key is not found in map.
+// Second return parameter is true if key was found, otherwise false.
+func (m *SyncMap[TKey, TValue]) Get(key TKey) (value TValue, found bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.Get(key)
+}
+
+// Remove removes the element from the map by key.
+func (m *SyncMap[TKey, TValue]) Remove(key TKey) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.m.Remove(key)
+}
+
+// Pop removes the element from the map by key and returns its value under a single lock,
+// so no other goroutine can observe or remove the element in between.
+// Second return parameter is true if key was found, otherwise false.
+func (m *SyncMap[TKey, TValue]) Pop(key TKey) (value TValue, found bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.m.Pop(key)
+}
+
+// Empty returns true if map does not contain any elements
+func (m *SyncMap[TKey, TValue]) Empty() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.Empty()
+}
+
+// Size returns number of elements in the map.
+func (m *SyncMap[TKey, TValue]) Size() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.Size()
+}
+
+// Keys returns all keys (random order).
+func (m *SyncMap[TKey, TValue]) Keys() []TKey {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.Keys()
+}
+
+// Values returns all values (random order).
+func (m *SyncMap[TKey, TValue]) Values() []TValue {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.Values()
+}
+
+// Clear removes all elements from the map.
+func (m *SyncMap[TKey, TValue]) Clear() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.m.Clear()
+}
+
+// Each calls the given function once for each element (random order), passing that element's key and value.
+// The elements are snapshotted under the read lock and the function is called without holding any lock,
+// so it may safely access the map. Changes made after the snapshot are not visited.
+func (m *SyncMap[TKey, TValue]) Each(f func(key TKey, value TValue)) {
+	m.mutex.RLock()
+	keys := make([]TKey, 0, len(m.m.m))
+	values := make([]TValue, 0, len(m.m.m))
+	for key, value := range m.m.m {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	m.mutex.RUnlock()
+	for i, key := range keys {
+		f(key, values[i])
+	}
+}
+
+// Iterator returns a stateful iterator whose elements are key/value pairs (random order).
+// The elements are snapshotted under the read lock and the iterator never accesses the map itself,
+// so it is safe to use while other goroutines modify the map. Changes made after the snapshot are not visited.
+func (m *SyncMap[TKey, TValue]) Iterator() Iterator[TKey, TValue] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.Iterator()
+}
+
+// String returns a string representation of container
+func (m *SyncMap[TKey, TValue]) String() string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.m.String()
+}
@@ -4,7 +4,10 @@
 
 package linkedhashmap
 
-import "github.com/a234567894/gods/containers"
+import (
+	"github.com/a234567894/gods/containers"
+	"github.com/a234567894/gods/maps"
+)
 
 // Assert Enumerable implementation
 var _ containers.EnumerableWithKey[int, int] = (*Map[int, int])(nil)
@@ -17,8 +20,28 @@ func (m *Map[TKey, TValue]) Each(f func(key TKey, value TValue)) {
 	}
 }
 
-// Map invokes the given function once for each element and returns a container
+// EachWindow slides a window of the given size over the elements in insertion order and calls the given function
+// once per window position, passing the entries in the window, i.e. size()-size+1 times in total.
+// The function is not called if size is not positive or greater than the size of the map.
+// Windows share memory with each other, so the passed slice must not be modified or retained across calls.
+func (m *Map[TKey, TValue]) EachWindow(size int, f func(window []maps.Entry[TKey, TValue])) {
+	if size < 1 || size > m.Size() {
+		return
+	}
+	entries := make([]maps.Entry[TKey, TValue], 0, m.Size())
+	iterator := m.Iterator()
+	for iterator.Next() {
+		entries = append(entries, maps.Entry[TKey, TValue]{Key: iterator.Key(), Value: iterator.Value()})
+	}
+	for i := 0; i+size <= len(entries); i++ {
+		f(entries[i : i+size : i+size])
+	}
+}
+
+// Map invokes the given function once for each element (insertion order) and returns a container
 // containing the values returned by the given function as key/value pairs.
+// If the function returns the same key for several elements, the last returned value wins and the key
+// keeps the position of its first occurrence.
 func (m *Map[TKey, TValue]) Map(f func(key1 TKey, value1 TValue) (TKey, TValue)) *Map[TKey, TValue] {
 	newMap := New[TKey, TValue]()
 	iterator := m.Iterator()
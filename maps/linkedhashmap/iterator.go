@@ -7,11 +7,15 @@ package linkedhashmap
 import (
 	"github.com/a234567894/gods/containers"
 	"github.com/a234567894/gods/lists/doublylinkedlist"
+	"github.com/a234567894/gods/maps"
 )
 
 // Assert Iterator implementation
 var _ containers.ReverseIteratorWithKey[int, int] = (*Iterator[int, int])(nil)
 
+// Assert IteratorMap implementation
+var _ maps.IteratorMap[int, int] = (*Map[int, int])(nil)
+
 // Iterator holding the iterator's state
 type Iterator[TKey, TValue comparable] struct {
 	iterator doublylinkedlist.Iterator[TKey]
@@ -25,6 +29,12 @@ func (m *Map[TKey, TValue]) Iterator() Iterator[TKey, TValue] {
 		table:    m.table}
 }
 
+// IteratorWithKey returns a stateful iterator whose elements are key/value pairs.
+func (m *Map[TKey, TValue]) IteratorWithKey() containers.IteratorWithKey[TKey, TValue] {
+	iterator := m.Iterator()
+	return &iterator
+}
+
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
 // If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
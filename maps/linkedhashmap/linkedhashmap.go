@@ -91,6 +91,27 @@ func (m *Map[TKey, TValue]) Values() []TValue {
 	return values
 }
 
+// KeysReversed returns all keys in reverse insertion order, i.e. the most recently inserted key first.
+func (m *Map[TKey, TValue]) KeysReversed() []TKey {
+	keys := make([]TKey, 0, m.Size())
+	it := m.Iterator()
+	for it.End(); it.Prev(); {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+// ValuesReversed returns all values in reverse insertion order of their keys, i.e. the value of the most recently
+// inserted key first.
+func (m *Map[TKey, TValue]) ValuesReversed() []TValue {
+	values := make([]TValue, 0, m.Size())
+	it := m.Iterator()
+	for it.End(); it.Prev(); {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
 // Clear removes all elements from the map.
 func (m *Map[TKey, TValue]) Clear() {
 	m.table = make(map[TKey]TValue)
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/a234567894/gods/maps"
 )
 
 func TestMapPut(t *testing.T) {
@@ -139,6 +141,26 @@ func sameElements[T comparable](a []T, b []T) bool {
 	return true
 }
 
+func TestMapKeysValuesReversed(t *testing.T) {
+	m := New[string, int]()
+	if actualValue := m.KeysReversed(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 4) // keeps its position
+	if actualValue, expectedValue := fmt.Sprint(m.KeysReversed()), "[b a c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.ValuesReversed()), "[2 1 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if allocs := testing.AllocsPerRun(10, func() { m.KeysReversed() }); allocs != 1 {
+		t.Errorf("Got %v expected %v", allocs, 1)
+	}
+}
+
 func TestMapEach(t *testing.T) {
 	m := New[string, int]()
 	m.Put("c", 1)
@@ -191,6 +213,23 @@ func TestMapMap(t *testing.T) {
 	}
 }
 
+func TestMapMapCollisions(t *testing.T) {
+	m := New[string, int]()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("d", 4)
+	m.Put("b", 2)
+	mappedMap := m.Map(func(key string, value int) (string, int) {
+		if value%2 == 0 {
+			return "even", value
+		}
+		return "odd", value
+	})
+	if actualValue, expectedValue := mappedMap.String(), "LinkedHashMap\nmap[odd:1 even:2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestMapSelect(t *testing.T) {
 	m := New[string, int]()
 	m.Put("c", 3)
@@ -291,6 +330,50 @@ func TestMapChaining(t *testing.T) {
 	}
 }
 
+func TestMapEachWindow(t *testing.T) {
+	m := New[string, int]()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	windows := func(size int) string {
+		str := ""
+		m.EachWindow(size, func(window []maps.Entry[string, int]) {
+			str += "["
+			for _, entry := range window {
+				str += fmt.Sprintf("%v:%v", entry.Key, entry.Value)
+			}
+			str += "]"
+		})
+		return str
+	}
+	tests := [][]interface{}{
+		{1, "[c:3][a:1][b:2]"},
+		{2, "[c:3a:1][a:1b:2]"},
+		{3, "[c:3a:1b:2]"},
+		{4, ""},
+		{0, ""},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := windows(test[0].(int)), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMapIteratorWithKey(t *testing.T) {
+	var m maps.IteratorMap[int, string] = New[int, string]()
+	m.Put(2, "b")
+	m.Put(1, "a")
+	keys := ""
+	for it := m.IteratorWithKey(); it.Next(); {
+		keys += fmt.Sprint(it.Key(), it.Value())
+	}
+	if actualValue, expectedValue := keys, "2b1a"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestMapIteratorNextOnEmpty(t *testing.T) {
 	m := New[int, int]()
 	it := m.Iterator()
@@ -0,0 +1,22 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package linkedhashmap
+
+import "iter"
+
+// Iter returns a sequence of the map's key/value pairs in insertion order for use with range-over-func,
+// e.g. for key, value := range m.Iter() { ... }. Iteration stops as soon as the loop body breaks.
+func (m *Map[TKey, TValue]) Iter() iter.Seq2[TKey, TValue] {
+	return func(yield func(TKey, TValue) bool) {
+		iterator := m.Iterator()
+		for iterator.Next() {
+			if !yield(iterator.Key(), iterator.Value()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package linkedhashmap
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMapIter(t *testing.T) {
+	m := New[int, string]()
+	for range m.Iter() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+	m.Put(3, "c")
+	m.Put(1, "a")
+	m.Put(2, "b")
+
+	str := ""
+	for key, value := range m.Iter() {
+		str += fmt.Sprint(key, value)
+		if key == 1 {
+			break
+		}
+	}
+	if actualValue, expectedValue := str, "3c1a"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
@@ -32,9 +32,73 @@ type Map[TKey, TValue comparable] interface {
 	// String() string
 }
 
+// Entry is a key/value pair shared by all maps, e.g. to return the contents of a map as a slice.
+type Entry[TKey, TValue comparable] struct {
+	Key   TKey
+	Value TValue
+}
+
 // BidiMap interface that all bidirectional maps implement (extends the Map interface)
 type BidiMap[TKey, TValue comparable] interface {
 	GetKey(value TKey) (key TKey, found bool)
 
 	Map[TKey, TValue]
 }
+
+// IteratorMap interface that all maps with a uniform iteration contract implement (extends the Map interface)
+type IteratorMap[TKey, TValue comparable] interface {
+	// IteratorWithKey returns a stateful iterator over the key/value pairs of the map, in the map's own order.
+	IteratorWithKey() containers.IteratorWithKey[TKey, TValue]
+
+	Map[TKey, TValue]
+}
+
+// Equal returns true if both maps have the same size and hold equal values under the same keys,
+// regardless of their implementation or order.
+func Equal[TKey, TValue comparable](a, b Map[TKey, TValue]) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	for _, key := range a.Keys() {
+		if valueB, found := b.Get(key); !found {
+			return false
+		} else if valueA, _ := a.Get(key); valueA != valueB {
+			return false
+		}
+	}
+	return true
+}
+
+// Diff compares map a with map b and returns the keys that are only in b (added), only in a (removed),
+// and in both but with different values (changed).
+// Keys are listed in the order of the respective map's Keys().
+func Diff[TKey, TValue comparable](a, b Map[TKey, TValue]) (added, removed, changed []TKey) {
+	for _, key := range a.Keys() {
+		valueA, _ := a.Get(key)
+		if valueB, found := b.Get(key); !found {
+			removed = append(removed, key)
+		} else if valueA != valueB {
+			changed = append(changed, key)
+		}
+	}
+	for _, key := range b.Keys() {
+		if _, found := a.Get(key); !found {
+			added = append(added, key)
+		}
+	}
+	return added, removed, changed
+}
+
+// Merge puts all entries of map src into map dst, regardless of their implementations.
+// For keys present in both maps, the value stored in dst is the one returned by the given policy function,
+// which receives the key, the value in dst and the value in src. A nil policy lets the value in src win.
+// Entries are merged in the order of src's Keys(). Map src is not modified.
+func Merge[TKey, TValue comparable](dst, src Map[TKey, TValue], policy func(key TKey, a, b TValue) TValue) {
+	for _, key := range src.Keys() {
+		value, _ := src.Get(key)
+		if existing, found := dst.Get(key); found && policy != nil {
+			value = policy(key, existing, value)
+		}
+		dst.Put(key, value)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maps_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/a234567894/gods/maps"
+	"github.com/a234567894/gods/maps/hashmap"
+	"github.com/a234567894/gods/maps/treemap"
+)
+
+func TestEqual(t *testing.T) {
+	a := hashmap.New[int, string]()
+	b := treemap.NewWithIntComparator[int, string]()
+	if actualValue, expectedValue := maps.Equal[int, string](a, b), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	a.Put(1, "a")
+	a.Put(2, "b")
+	b.Put(2, "b")
+	b.Put(1, "a")
+	if actualValue, expectedValue := maps.Equal[int, string](a, b), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	b.Put(2, "x")
+	if actualValue, expectedValue := maps.Equal[int, string](a, b), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	b.Remove(2)
+	b.Put(3, "b")
+	if actualValue, expectedValue := maps.Equal[int, string](a, b), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	b.Remove(3)
+	if actualValue, expectedValue := maps.Equal[int, string](a, b), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := treemap.NewWithIntComparator[int, string]()
+	a.Put(1, "a")
+	a.Put(2, "b")
+	a.Put(3, "c")
+	b := hashmap.New[int, string]()
+	b.Put(2, "b")
+	b.Put(3, "x")
+	b.Put(4, "d")
+
+	added, removed, changed := maps.Diff[int, string](a, b)
+	if actualValue, expectedValue := fmt.Sprint(added, removed, changed), "[4] [1] [3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	added, removed, changed = maps.Diff[int, string](a, a)
+	if len(added) != 0 || len(removed) != 0 || len(changed) != 0 {
+		t.Errorf("Got %v %v %v expected no differences", added, removed, changed)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	dst := hashmap.New[string, int]()
+	dst.Put("a", 1)
+	dst.Put("b", 2)
+	src := treemap.NewWithStringComparator[string, int]()
+	src.Put("b", 10)
+	src.Put("c", 3)
+
+	maps.Merge[string, int](dst, src, func(key string, a, b int) int {
+		return a + b
+	})
+	if actualValue, expectedValue := fmt.Sprint(dst.ToMap()), "map[a:1 b:12 c:3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := src.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// nil policy lets the source win, here merging a hash map into a tree map
+	maps.Merge[string, int](src, dst, nil)
+	if actualValue, expectedValue := fmt.Sprint(src.Keys(), src.Values()), "[a b c] [1 12 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
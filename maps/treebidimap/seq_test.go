@@ -0,0 +1,30 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package treebidimap
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMapIter(t *testing.T) {
+	m := NewWithStringComparators[string, string]()
+	for range m.Iter() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+	m.Put("c", "z")
+	m.Put("a", "x")
+	m.Put("b", "y")
+
+	str := ""
+	for key, value := range m.Iter() {
+		str += fmt.Sprint(key, value)
+	}
+	if actualValue, expectedValue := str, "axbycz"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
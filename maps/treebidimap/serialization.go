@@ -5,7 +5,9 @@
 package treebidimap
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 
 	"github.com/a234567894/gods/containers"
 )
@@ -14,29 +16,78 @@ import (
 var _ containers.JSONSerializer = (*Map[int, int])(nil)
 var _ containers.JSONDeserializer = (*Map[int, int])(nil)
 
-// ToJSON outputs the JSON representation of the map.
+// jsonEntry is the JSON representation of a single key/value pair of the map.
+type jsonEntry[TKey, TValue comparable] struct {
+	Key   TKey   `json:"key"`
+	Value TValue `json:"value"`
+}
+
+// ToJSON outputs the JSON representation of the map, i.e. an array of key/value objects in key order.
+// Unlike a JSON object, the array keeps keys typed and in the order of the map's key comparator.
 func (m *Map[TKey, TValue]) ToJSON() ([]byte, error) {
-	elements := make(map[TKey]TValue)
+	elements := make([]jsonEntry[TKey, TValue], 0, m.Size())
 	it := m.Iterator()
 	for it.Next() {
-		elements[it.Key()] = it.Value()
+		elements = append(elements, jsonEntry[TKey, TValue]{Key: it.Key(), Value: it.Value()})
 	}
 	return json.Marshal(&elements)
 }
 
 // FromJSON populates the map from the input JSON representation.
+// The input is either an array of key/value objects as output by ToJSON or a JSON object mapping keys to values.
+// Pairs are inserted in input order, so if several keys share a value, the later one wins as it would with Put.
 func (m *Map[TKey, TValue]) FromJSON(data []byte) error {
-	elements := make(map[TKey]TValue)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '[' {
+		return m.fromJSONObject(data)
+	}
+	var elements []jsonEntry[TKey, TValue]
 	err := json.Unmarshal(data, &elements)
 	if err == nil {
 		m.Clear()
-		for key, value := range elements {
-			m.Put(key, value)
+		for _, element := range elements {
+			m.Put(element.Key, element.Value)
 		}
 	}
 	return err
 }
 
+// Populates the map from a JSON object, decoding its pairs one at a time to keep them in input order.
+func (m *Map[TKey, TValue]) fromJSONObject(data []byte) error {
+	// Decode the whole object first, so that malformed input leaves the map untouched
+	if err := json.Unmarshal(data, &map[TKey]TValue{}); err != nil {
+		return err
+	}
+	m.Clear()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// The input was validated above, so it is either an object or null, which leaves the map empty
+	if token, err := decoder.Token(); err != nil || token == nil {
+		return err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		// Decode the key along with its value, as keys follow the rules of encoding/json for map keys
+		name, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		pair := make(map[TKey]TValue, 1)
+		if err := json.Unmarshal([]byte(fmt.Sprintf("{%s:%s}", name, value)), &pair); err != nil {
+			return err
+		}
+		for key, value := range pair {
+			m.Put(key, value)
+		}
+	}
+	return nil
+}
+
 // UnmarshalJSON @implements json.Unmarshaler
 func (m *Map[TKey, TValue]) UnmarshalJSON(bytes []byte) error {
 	return m.FromJSON(bytes)
@@ -19,6 +19,7 @@ package treebidimap
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/a234567894/gods/maps"
@@ -93,6 +94,84 @@ func (m *Map[TKey, TValue]) GetKey(value TValue) (key TKey, found bool) {
 	return *new(TKey), false
 }
 
+// Inverse returns a new bidirectional map whose keys are this map's values and whose values are this map's keys.
+// Keys of the inverse map are ordered by this map's value comparator and vice versa.
+// The inverse map is an independent copy, i.e. subsequent changes to either map are not reflected in the other.
+func (m *Map[TKey, TValue]) Inverse() *Map[TValue, TKey] {
+	inverse := NewWith[TValue, TKey](m.valueComparator, m.keyComparator)
+	it := m.inverseMap.Iterator()
+	for it.Next() {
+		inverse.Put(it.Key(), it.Value().key)
+	}
+	return inverse
+}
+
+// FloorKey finds the entry with the largest key that is less than or equal to the given key.
+// Third return parameter is true if such an entry was found, otherwise false.
+// Key should adhere to the key comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) FloorKey(key TKey) (foundKey TKey, foundValue TValue, found bool) {
+	if node, found := m.forwardMap.Floor(key); found {
+		return node.Value.key, node.Value.value, true
+	}
+	return foundKey, foundValue, false
+}
+
+// CeilingKey finds the entry with the smallest key that is greater than or equal to the given key.
+// Third return parameter is true if such an entry was found, otherwise false.
+// Key should adhere to the key comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) CeilingKey(key TKey) (foundKey TKey, foundValue TValue, found bool) {
+	if node, found := m.forwardMap.Ceiling(key); found {
+		return node.Value.key, node.Value.value, true
+	}
+	return foundKey, foundValue, false
+}
+
+// FloorValue finds the entry with the largest value that is less than or equal to the given value.
+// Third return parameter is true if such an entry was found, otherwise false.
+// Value should adhere to the value comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) FloorValue(value TValue) (foundKey TKey, foundValue TValue, found bool) {
+	if node, found := m.inverseMap.Floor(value); found {
+		return node.Value.key, node.Value.value, true
+	}
+	return foundKey, foundValue, false
+}
+
+// CeilingValue finds the entry with the smallest value that is greater than or equal to the given value.
+// Third return parameter is true if such an entry was found, otherwise false.
+// Value should adhere to the value comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) CeilingValue(value TValue) (foundKey TKey, foundValue TValue, found bool) {
+	if node, found := m.inverseMap.Ceiling(value); found {
+		return node.Value.key, node.Value.value, true
+	}
+	return foundKey, foundValue, false
+}
+
+// KeyComparator returns the comparator ordering the keys of the map.
+func (m *Map[TKey, TValue]) KeyComparator() utils.Comparator {
+	return m.keyComparator
+}
+
+// ValueComparator returns the comparator ordering the values of the map.
+func (m *Map[TKey, TValue]) ValueComparator() utils.Comparator {
+	return m.valueComparator
+}
+
+// Merge puts all pairs of the other map into this map in the other map's key order, as if by calling Put for each,
+// so that pairs of this map sharing a key or a value with an incoming pair are replaced and the map stays a bijection.
+// The other map is not modified.
+// Panics if the maps do not have the same key and value comparators.
+// As functions cannot be compared in Go, the check is best-effort: comparators are told apart by their code only,
+// so closures built by the same function (e.g. utils.Reverse of two different comparators) are deemed the same.
+func (m *Map[TKey, TValue]) Merge(other *Map[TKey, TValue]) {
+	if !sameComparator(m.keyComparator, other.keyComparator) || !sameComparator(m.valueComparator, other.valueComparator) {
+		panic("Incompatible maps, should have the same key and value comparators")
+	}
+	it := other.forwardMap.Iterator()
+	for it.Next() {
+		m.Put(it.Key(), it.Value().value)
+	}
+}
+
 // Remove removes the element from the map by key.
 func (m *Map[TKey, TValue]) Remove(key TKey) {
 	if d, found := m.forwardMap.Get(key); found {
@@ -136,3 +215,8 @@ func (m *Map[TKey, TValue]) String() string {
 	}
 	return strings.TrimRight(str, " ") + "]"
 }
+
+// Reports whether both comparators run the same code, which is all that can be told of functions in Go.
+func sameComparator(a, b utils.Comparator) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
@@ -154,6 +154,41 @@ func TestMapGetKey(t *testing.T) {
 	}
 }
 
+func TestMapInverse(t *testing.T) {
+	m := NewWith[string, int](utils.StringComparator, utils.IntComparator)
+	m.Put("a", 3)
+	m.Put("b", 1)
+	m.Put("c", 2)
+
+	inverse := m.Inverse()
+	if actualValue, expectedValue := fmt.Sprintf("%v", inverse.Keys()), "[1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", inverse.Values()), "[a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := inverse.Get(1); actualValue != "b" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "b")
+	}
+	if actualValue, found := inverse.GetKey("a"); actualValue != 3 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	// inverse uses the swapped comparators
+	inverse.Put(0, "d")
+	if actualValue, expectedValue := fmt.Sprintf("%v", inverse.Keys()), "[0 1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// inverse is a copy
+	if actualValue := m.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", m.Inverse().Inverse().Keys()), "[a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func sameElements[T comparable](a []T, b []T) bool {
 	if len(a) != len(b) {
 		return false
@@ -173,6 +208,79 @@ func sameElements[T comparable](a []T, b []T) bool {
 	return true
 }
 
+func TestMapFloorCeiling(t *testing.T) {
+	m := NewWithIntComparators[int, int]()
+	m.Put(1, 50)
+	m.Put(5, 10)
+	m.Put(9, 30)
+
+	tests := [][]interface{}{
+		{m.FloorKey, 6, 5, 10, true},
+		{m.FloorKey, 0, 0, 0, false},
+		{m.CeilingKey, 6, 9, 30, true},
+		{m.CeilingKey, 10, 0, 0, false},
+		{m.FloorValue, 40, 9, 30, true},
+		{m.FloorValue, 5, 0, 0, false},
+		{m.CeilingValue, 10, 5, 10, true},
+		{m.CeilingValue, 51, 0, 0, false},
+	}
+	for _, test := range tests {
+		key, value, found := test[0].(func(int) (int, int, bool))(test[1].(int))
+		if actualValue, expectedValue := key, test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := value, test[3]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := found, test[4]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMapMerge(t *testing.T) {
+	m := NewWithIntComparators[int, int]()
+	m.Put(1, 10)
+	m.Put(2, 20)
+	m.Put(3, 30)
+	other := NewWithIntComparators[int, int]()
+	other.Put(4, 10) // value of key 1
+	other.Put(2, 50) // new value for key 2
+	other.Put(5, 60)
+
+	m.Merge(other)
+	if actualValue, expectedValue := fmt.Sprint(m.Keys(), m.Values()), "[2 3 4 5] [10 30 50 60]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, key := range m.Keys() {
+		value, _ := m.Get(key)
+		if actualValue, found := m.GetKey(value); actualValue != key || !found {
+			t.Errorf("Got %v expected %v", actualValue, key)
+		}
+	}
+	if actualValue, expectedValue := other.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.KeyComparator()(1, 2), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// comparators built by the same function are deemed the same
+	reversed := NewWith[int, int](utils.Reverse(utils.IntComparator), utils.Reverse(utils.IntComparator))
+	reversed.Put(1, 70)
+	reversed.Merge(NewWith[int, int](utils.Reverse(utils.IntComparator), utils.Reverse(utils.IntComparator)))
+	if actualValue, expectedValue := reversed.Size(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Merging maps with different comparators should panic")
+		}
+	}()
+	m.Merge(NewWith[int, int](utils.IntComparator, utils.Reverse(utils.IntComparator)))
+}
+
 func TestMapEach(t *testing.T) {
 	m := NewWith[string, int](utils.StringComparator, utils.IntComparator)
 	m.Put("c", 3)
@@ -623,12 +731,75 @@ func TestMapSerialization(t *testing.T) {
 	}
 }
 
+func TestMapSerializationDuplicateValues(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		m := NewWithStringComparators[string, string]()
+		err := m.FromJSON([]byte(`[{"key":"c","value":"x"},{"key":"a","value":"x"},{"key":"b","value":"y"},{"key":"d","value":"y"}]`))
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := m.String(), "TreeBidiMap\nmap[a:x d:y]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, found := m.GetKey("y"); actualValue != "d" || !found {
+			t.Errorf("Got %v expected %v", actualValue, "d")
+		}
+		serialized, err := m.ToJSON()
+		if actualValue, expectedValue := string(serialized), `[{"key":"a","value":"x"},{"key":"d","value":"y"}]`; actualValue != expectedValue || err != nil {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+
+		// the later pair wins in the object form too, regardless of key order
+		err = m.FromJSON([]byte(`{"c":"x","a":"x","b":"y","d":"y"}`))
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := m.String(), "TreeBidiMap\nmap[a:x d:y]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMapSerializationKeyOrder(t *testing.T) {
+	m := NewWith[int, string](utils.Reverse(utils.IntComparator), utils.StringComparator)
+	m.Put(9, "a")
+	m.Put(10, "b")
+	m.Put(1, "c")
+	serialized, err := m.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(serialized), `[{"key":10,"value":"b"},{"key":9,"value":"a"},{"key":1,"value":"c"}]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	deserialized := NewWith[int, string](utils.Reverse(utils.IntComparator), utils.StringComparator)
+	if err := deserialized.FromJSON(serialized); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(deserialized.Keys()), "[10 9 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if err := deserialized.FromJSON([]byte(`{"1":"x","2":`)); err == nil {
+		t.Errorf("Expected error on malformed input")
+	}
+	if actualValue, expectedValue := deserialized.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestMapString(t *testing.T) {
 	c := NewWithStringComparators[string, string]()
 	c.Put("a", "a")
 	if !strings.HasPrefix(c.String(), "TreeBidiMap") {
 		t.Errorf("String should start with container name")
 	}
+	c.Put("c", "z")
+	c.Put("b", "y")
+	if actualValue, expectedValue := c.String(), "TreeBidiMap\nmap[a:a b:y c:z]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
 }
 
 // noinspection GoBoolExpressions
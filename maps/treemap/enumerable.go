@@ -44,6 +44,34 @@ func (m *Map[TKey, TValue]) Select(f func(key TKey, value TValue) bool) *Map[TKe
 	return newMap
 }
 
+// Reject returns a new container containing all elements for which the given function returns a false value.
+func (m *Map[TKey, TValue]) Reject(f func(key TKey, value TValue) bool) *Map[TKey, TValue] {
+	return m.Select(func(key TKey, value TValue) bool {
+		return !f(key, value)
+	})
+}
+
+// Partition returns two new containers in a single pass, the first containing all elements for which the given
+// function returns a true value and the second containing all other elements.
+// Both containers keep the comparator of this container and are built bottom-up from the ordered elements in O(n).
+func (m *Map[TKey, TValue]) Partition(f func(key TKey, value TValue) bool) (matching, rest *Map[TKey, TValue]) {
+	var matchingKeys, restKeys []TKey
+	var matchingValues, restValues []TValue
+	iterator := m.Iterator()
+	for iterator.Next() {
+		if f(iterator.Key(), iterator.Value()) {
+			matchingKeys, matchingValues = append(matchingKeys, iterator.Key()), append(matchingValues, iterator.Value())
+		} else {
+			restKeys, restValues = append(restKeys, iterator.Key()), append(restValues, iterator.Value())
+		}
+	}
+	matching = &Map[TKey, TValue]{tree: rbt.NewWith[TKey, TValue](m.tree.Comparator)}
+	matching.tree.FromSorted(matchingKeys, matchingValues)
+	rest = &Map[TKey, TValue]{tree: rbt.NewWith[TKey, TValue](m.tree.Comparator)}
+	rest.tree.FromSorted(restKeys, restValues)
+	return matching, rest
+}
+
 // Any passes each element of the container to the given function and
 // returns true if the function ever returns true for any element.
 func (m *Map[TKey, TValue]) Any(f func(key TKey, value TValue) bool) bool {
@@ -80,3 +108,14 @@ func (m *Map[TKey, TValue]) Find(f func(key TKey, value TValue) bool) (TKey, TVa
 	}
 	return *new(TKey), *new(TValue)
 }
+
+// ScanValues walks the map in key order, folding each value into a running accumulator with the
+// given combine function and passing that element's key together with the running accumulator to emit.
+// The initial accumulator is given by acc (e.g. zero for a running sum).
+func (m *Map[TKey, TValue]) ScanValues(acc TValue, combine func(acc, value TValue) TValue, emit func(key TKey, runningAcc TValue)) {
+	iterator := m.Iterator()
+	for iterator.Next() {
+		acc = combine(acc, iterator.Value())
+		emit(iterator.Key(), acc)
+	}
+}
@@ -6,12 +6,16 @@ package treemap
 
 import (
 	"github.com/a234567894/gods/containers"
+	"github.com/a234567894/gods/maps"
 	rbt "github.com/a234567894/gods/trees/redblacktree"
 )
 
 // Assert Iterator implementation
 var _ containers.ReverseIteratorWithKey[int, int] = (*Iterator[int, int])(nil)
 
+// Assert IteratorMap implementation
+var _ maps.IteratorMap[int, int] = (*Map[int, int])(nil)
+
 // Iterator holding the iterator's state
 type Iterator[TKey, TValue comparable] struct {
 	iterator rbt.Iterator[TKey, TValue]
@@ -22,6 +26,20 @@ func (m *Map[TKey, TValue]) Iterator() Iterator[TKey, TValue] {
 	return Iterator[TKey, TValue]{iterator: m.tree.Iterator()}
 }
 
+// IteratorWithKey returns a stateful iterator whose elements are key/value pairs.
+func (m *Map[TKey, TValue]) IteratorWithKey() containers.IteratorWithKey[TKey, TValue] {
+	iterator := m.Iterator()
+	return &iterator
+}
+
+// ReverseIterator returns a stateful iterator whose elements are key/value pairs, positioned one-past-the-end.
+// Call Prev() to fetch the elements in descending key order.
+func (m *Map[TKey, TValue]) ReverseIterator() Iterator[TKey, TValue] {
+	it := m.Iterator()
+	it.End()
+	return it
+}
+
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
 // If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
@@ -0,0 +1,63 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package treemap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/a234567894/gods/maps"
+)
+
+func TestMapEntries(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	for range m.Entries() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+
+	m.Put(3, "c")
+	m.Put(1, "a")
+	m.Put(2, "b")
+
+	entries := []maps.Entry[int, string]{}
+	for entry := range m.Entries() {
+		entries = append(entries, entry)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", entries), "[{1 a} {2 b} {3 c}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// early break
+	count := 0
+	for entry := range m.Entries() {
+		count++
+		if entry.Key == 2 {
+			break
+		}
+	}
+	if actualValue, expectedValue := count, 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIter(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	m.Put(3, "c")
+	m.Put(1, "a")
+	m.Put(2, "b")
+
+	str := ""
+	for key, value := range m.Iter() {
+		str += fmt.Sprint(key, value)
+		if key == 2 {
+			break
+		}
+	}
+	if actualValue, expectedValue := str, "1a2b"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
@@ -5,12 +5,16 @@
 package treemap
 
 import (
+	"io"
+
 	"github.com/a234567894/gods/containers"
 )
 
 // Assert Serialization implementation
 var _ containers.JSONSerializer = (*Map[int, int])(nil)
 var _ containers.JSONDeserializer = (*Map[int, int])(nil)
+var _ containers.JSONStreamSerializer = (*Map[int, int])(nil)
+var _ containers.JSONStreamDeserializer = (*Map[int, int])(nil)
 
 // ToJSON outputs the JSON representation of the map.
 func (m *Map[TKey, TValue]) ToJSON() ([]byte, error) {
@@ -22,6 +26,16 @@ func (m *Map[TKey, TValue]) FromJSON(data []byte) error {
 	return m.tree.FromJSON(data)
 }
 
+// WriteJSON writes the same JSON representation as ToJSON to the writer, one element at a time.
+func (m *Map[TKey, TValue]) WriteJSON(w io.Writer) error {
+	return m.tree.WriteJSON(w)
+}
+
+// ReadJSON populates the map from the JSON representation read from the reader, one element at a time.
+func (m *Map[TKey, TValue]) ReadJSON(r io.Reader) error {
+	return m.tree.ReadJSON(r)
+}
+
 // UnmarshalJSON @implements json.Unmarshaler
 func (m *Map[TKey, TValue]) UnmarshalJSON(bytes []byte) error {
 	return m.FromJSON(bytes)
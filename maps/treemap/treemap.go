@@ -43,12 +43,85 @@ func NewWithStringComparator[TKey, TValue comparable]() *Map[TKey, TValue] {
 	return &Map[TKey, TValue]{tree: rbt.NewWithStringComparator[TKey, TValue]()}
 }
 
+// NewFromMap instantiates a tree map with the custom comparator holding a copy of the given native map.
+// The keys are sorted once and the tree is built bottom-up, which is cheaper than putting them one by one.
+func NewFromMap[TKey, TValue comparable](comparator utils.Comparator, entries map[TKey]TValue) *Map[TKey, TValue] {
+	keys := make([]TKey, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	utils.Sort(keys, comparator)
+	values := make([]TValue, len(keys))
+	for i, key := range keys {
+		values[i] = entries[key]
+	}
+	m := NewWith[TKey, TValue](comparator)
+	m.tree.FromSorted(keys, values)
+	return m
+}
+
+// NewFromSortedSlices instantiates a tree map with the custom comparator from two parallel slices of keys and values,
+// i.e. keys[i] maps to values[i]. If the keys are in ascending order, the tree is built bottom-up in O(n);
+// repeated keys keep the last value. Should the keys turn out not to be in ascending order,
+// they are inserted one by one as if by calling Put. The slices are not retained.
+// Returns an error if the slices differ in length.
+func NewFromSortedSlices[TKey, TValue comparable](comparator utils.Comparator, keys []TKey, values []TValue) (*Map[TKey, TValue], error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("keys and values differ in length: %d != %d", len(keys), len(values))
+	}
+	m := NewWith[TKey, TValue](comparator)
+	m.loadSorted(append([]TKey(nil), keys...), append([]TValue(nil), values...))
+	return m, nil
+}
+
 // Put inserts key-value pair into the map.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (m *Map[TKey, TValue]) Put(key TKey, value TValue) {
 	m.tree.Put(key, value)
 }
 
+// PutWithPrevious inserts key-value pair into the map like Put and returns the value it replaced.
+// Second return parameter is true if an existing value was replaced, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) PutWithPrevious(key TKey, value TValue) (old TValue, replaced bool) {
+	if node := m.tree.GetNode(key); node != nil {
+		old = node.Value
+		node.Key, node.Value = key, value
+		return old, true
+	}
+	m.tree.Put(key, value)
+	return old, false
+}
+
+// PutAll inserts all entries of the other map into this map as if by calling Put for each of them,
+// i.e. values of keys present in both maps are replaced by the other map's values.
+// Keys of the other map should adhere to this map's comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) PutAll(other maps.Map[TKey, TValue]) {
+	for _, key := range other.Keys() {
+		value, _ := other.Get(key)
+		m.Put(key, value)
+	}
+}
+
+// LoadFromChannel drains the channel until it is closed and inserts all received entries into the map.
+// If assumeSorted is true and the map is empty, entries are collected and the map is built bottom-up in O(n);
+// repeated keys keep the last value. Should the entries turn out not to be in ascending key order
+// (or the map is not empty), they are inserted one by one as if by calling Put.
+func (m *Map[TKey, TValue]) LoadFromChannel(ch <-chan maps.Entry[TKey, TValue], assumeSorted bool) {
+	if !assumeSorted || !m.Empty() {
+		for entry := range ch {
+			m.Put(entry.Key, entry.Value)
+		}
+		return
+	}
+	keys, values := []TKey{}, []TValue{}
+	for entry := range ch {
+		keys = append(keys, entry.Key)
+		values = append(values, entry.Value)
+	}
+	m.loadSorted(keys, values)
+}
+
 // Get searches the element in the map by key and returns its value or nil if key is not found in tree.
 // Second return parameter is true if key was found, otherwise false.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
@@ -56,6 +129,22 @@ func (m *Map[TKey, TValue]) Get(key TKey) (value TValue, found bool) {
 	return m.tree.Get(key)
 }
 
+// Modify calls the given function with a pointer to the stored value of the key, so that the value can be
+// updated in place without copying it out with Get and back in with Put.
+// Returns false without calling the function if the key is not in the map.
+// The pointer must not be retained after the function returns: the map moves values between its nodes
+// when elements are removed, so a retained pointer may later alias the value of a different key.
+// The function must not modify the map itself.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) Modify(key TKey, f func(value *TValue)) bool {
+	node := m.tree.GetNode(key)
+	if node == nil {
+		return false
+	}
+	f(&node.Value)
+	return true
+}
+
 // Remove removes the element from the map by key.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (m *Map[TKey, TValue]) Remove(key TKey) {
@@ -82,6 +171,34 @@ func (m *Map[TKey, TValue]) Values() []TValue {
 	return m.tree.Values()
 }
 
+// KeysDescending returns all keys in reverse order.
+func (m *Map[TKey, TValue]) KeysDescending() []TKey {
+	keys := make([]TKey, 0, m.Size())
+	for it := m.ReverseIterator(); it.Prev(); {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+// ValuesDescending returns all values in reverse order based on the key.
+func (m *Map[TKey, TValue]) ValuesDescending() []TValue {
+	values := make([]TValue, 0, m.Size())
+	for it := m.ReverseIterator(); it.Prev(); {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+// EntrySet returns all key/value pairs in-order based on the key.
+// The returned entries are copies, so modifying them does not affect the map.
+func (m *Map[TKey, TValue]) EntrySet() []maps.Entry[TKey, TValue] {
+	entries := make([]maps.Entry[TKey, TValue], 0, m.Size())
+	for it := m.Iterator(); it.Next(); {
+		entries = append(entries, maps.Entry[TKey, TValue]{Key: it.Key(), Value: it.Value()})
+	}
+	return entries
+}
+
 // Clear removes all elements from the map.
 func (m *Map[TKey, TValue]) Clear() {
 	m.tree.Clear()
@@ -89,6 +206,8 @@ func (m *Map[TKey, TValue]) Clear() {
 
 // Min returns the minimum key and its value from the tree map.
 // Returns nil, nil if map is empty.
+//
+// Deprecated: Use MinEntry, which returns typed values.
 func (m *Map[TKey, TValue]) Min() (key interface{}, value interface{}) {
 	if node := m.tree.Left(); node != nil {
 		return node.Key, node.Value
@@ -98,6 +217,8 @@ func (m *Map[TKey, TValue]) Min() (key interface{}, value interface{}) {
 
 // Max returns the maximum key and its value from the tree map.
 // Returns nil, nil if map is empty.
+//
+// Deprecated: Use MaxEntry, which returns typed values.
 func (m *Map[TKey, TValue]) Max() (key interface{}, value interface{}) {
 	if node := m.tree.Right(); node != nil {
 		return node.Key, node.Value
@@ -105,6 +226,20 @@ func (m *Map[TKey, TValue]) Max() (key interface{}, value interface{}) {
 	return nil, nil
 }
 
+// MinEntry returns the minimum key and its value from the tree map.
+// Third return parameter is false if the map is empty.
+func (m *Map[TKey, TValue]) MinEntry() (key TKey, value TValue, found bool) {
+	node := m.tree.Left()
+	return entryOf(node, node != nil)
+}
+
+// MaxEntry returns the maximum key and its value from the tree map.
+// Third return parameter is false if the map is empty.
+func (m *Map[TKey, TValue]) MaxEntry() (key TKey, value TValue, found bool) {
+	node := m.tree.Right()
+	return entryOf(node, node != nil)
+}
+
 // Floor finds the floor key-value pair for the input key.
 // In case that no floor is found, then both returned values will be nil.
 // It's generally enough to check the first value (key) for nil, which determines if floor was found.
@@ -139,6 +274,271 @@ func (m *Map[TKey, TValue]) Ceiling(key TKey) (foundKey TKey, foundValue TValue)
 	return *new(TKey), *new(TValue)
 }
 
+// FloorEntry finds the largest key that is smaller than or equal to the given key and returns it with its value.
+// Unlike Floor, the third return parameter tells whether such a key was found, which disambiguates zero keys.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) FloorEntry(key TKey) (foundKey TKey, foundValue TValue, found bool) {
+	return entryOf(m.tree.Floor(key))
+}
+
+// CeilingEntry finds the smallest key that is larger than or equal to the given key and returns it with its value.
+// Unlike Ceiling, the third return parameter tells whether such a key was found, which disambiguates zero keys.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) CeilingEntry(key TKey) (foundKey TKey, foundValue TValue, found bool) {
+	return entryOf(m.tree.Ceiling(key))
+}
+
+// HigherEntry finds the smallest key that is strictly larger than the given key and returns it with its value.
+// Third return parameter is true if such a key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) HigherEntry(key TKey) (foundKey TKey, foundValue TValue, found bool) {
+	return entryOf(m.tree.Higher(key))
+}
+
+// LowerEntry finds the largest key that is strictly smaller than the given key and returns it with its value.
+// Third return parameter is true if such a key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) LowerEntry(key TKey) (foundKey TKey, foundValue TValue, found bool) {
+	return entryOf(m.tree.Lower(key))
+}
+
+// HigherKey finds the smallest key that is strictly larger than the given key.
+// Second return parameter is true if such a key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) HigherKey(key TKey) (foundKey TKey, found bool) {
+	foundKey, _, found = m.HigherEntry(key)
+	return foundKey, found
+}
+
+// LowerKey finds the largest key that is strictly smaller than the given key.
+// Second return parameter is true if such a key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) LowerKey(key TKey) (foundKey TKey, found bool) {
+	foundKey, _, found = m.LowerEntry(key)
+	return foundKey, found
+}
+
+// Reversed returns a new map with the same entries whose comparator is the reverse of this map's comparator,
+// so that its natural iteration order is descending. The returned map is independent of this map.
+func (m *Map[TKey, TValue]) Reversed() *Map[TKey, TValue] {
+	reversed := NewWith[TKey, TValue](utils.Reverse(m.tree.Comparator))
+	keys, values := make([]TKey, 0, m.Size()), make([]TValue, 0, m.Size())
+	for it := m.ReverseIterator(); it.Prev(); {
+		keys = append(keys, it.Key())
+		values = append(values, it.Value())
+	}
+	reversed.tree.FromSorted(keys, values)
+	return reversed
+}
+
+// PageFrom returns up to limit entries in key order, starting at the ceiling of the cursor key,
+// i.e. the cursor key itself or the next larger key if the cursor key is not (or no longer) in the map.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) PageFrom(cursor TKey, limit int) []maps.Entry[TKey, TValue] {
+	entries := []maps.Entry[TKey, TValue]{}
+	node, found := m.tree.Ceiling(cursor)
+	if !found {
+		return entries
+	}
+	for it := m.tree.IteratorAt(node); len(entries) < limit; it.Next() {
+		if it.Node() == nil {
+			break
+		}
+		entries = append(entries, maps.Entry[TKey, TValue]{Key: it.Key(), Value: it.Value()})
+	}
+	return entries
+}
+
+// RangeKeys returns all keys k with from <= k <= to in key order, or an empty slice if from > to.
+// It seeks to the first key in the range, so it takes O(k + log n) time for k returned keys.
+// Keys should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) RangeKeys(from, to TKey) []TKey {
+	keys := []TKey{}
+	m.eachInRange(from, to, func(key TKey, value TValue) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// RangeValues returns the values of all keys k with from <= k <= to in key order, or an empty slice if from > to.
+// It seeks to the first key in the range, so it takes O(k + log n) time for k returned values.
+// Keys should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) RangeValues(from, to TKey) []TValue {
+	values := []TValue{}
+	m.eachInRange(from, to, func(key TKey, value TValue) {
+		values = append(values, value)
+	})
+	return values
+}
+
+// CountRange returns the number of keys k with from <= k <= to, or 0 if from > to.
+// Keys should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) CountRange(from, to TKey) int {
+	count := 0
+	m.eachInRange(from, to, func(key TKey, value TValue) {
+		count++
+	})
+	return count
+}
+
+// SplitN divides the map into n contiguous chunks of nearly equal size, i.e. sizes of any two chunks differ by at most one.
+// Chunks preserve the ordering and the comparator of the map, so their concatenation equals the original map.
+// Leading chunks hold the extra elements, trailing chunks are empty if n exceeds the size of the map.
+// A value of n less than one is treated as one.
+func (m *Map[TKey, TValue]) SplitN(n int) []*Map[TKey, TValue] {
+	if n < 1 {
+		n = 1
+	}
+	chunks := make([]*Map[TKey, TValue], n)
+	size, remainder := m.Size()/n, m.Size()%n
+	iterator := m.Iterator()
+	for i := range chunks {
+		chunks[i] = NewWith[TKey, TValue](m.tree.Comparator)
+		chunkSize := size
+		if i < remainder {
+			chunkSize++
+		}
+		for j := 0; j < chunkSize && iterator.Next(); j++ {
+			chunks[i].Put(iterator.Key(), iterator.Value())
+		}
+	}
+	return chunks
+}
+
+// Split divides the map at the given key into a left map holding all keys less than the key
+// and a right map holding all keys greater than or equal to the key.
+// Both maps share the comparator of this map, which is left intact. Takes O(n) time.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) Split(key TKey) (left, right *Map[TKey, TValue]) {
+	var leftKeys, rightKeys []TKey
+	var leftValues, rightValues []TValue
+	for it := m.Iterator(); it.Next(); {
+		if m.tree.Comparator(it.Key(), key) < 0 {
+			leftKeys, leftValues = append(leftKeys, it.Key()), append(leftValues, it.Value())
+		} else {
+			rightKeys, rightValues = append(rightKeys, it.Key()), append(rightValues, it.Value())
+		}
+	}
+	left, right = NewWith[TKey, TValue](m.tree.Comparator), NewWith[TKey, TValue](m.tree.Comparator)
+	left.tree.FromSorted(leftKeys, leftValues)
+	right.tree.FromSorted(rightKeys, rightValues)
+	return left, right
+}
+
+// Quantile returns the entry at rank floor(q*(size-1)) in key order, i.e. q=0 yields the minimum,
+// q=1 the maximum and q=0.5 the (lower) median. Values of q outside of [0,1] are clamped.
+// Third return parameter is false if the map is empty.
+func (m *Map[TKey, TValue]) Quantile(q float64) (key TKey, value TValue, ok bool) {
+	size := m.Size()
+	if size == 0 {
+		return key, value, false
+	}
+	if q < 0 || q != q {
+		q = 0
+	} else if q > 1 {
+		q = 1
+	}
+	node, _ := m.tree.Select(int(q * float64(size-1)))
+	return node.Key, node.Value, true
+}
+
+// KeyAt returns the key with the given zero-based index in key order in O(log n).
+// Second return parameter is false if the index is out of range.
+func (m *Map[TKey, TValue]) KeyAt(index int) (key TKey, found bool) {
+	if node, found := m.tree.Select(index); found {
+		return node.Key, true
+	}
+	return key, false
+}
+
+// IndexOf returns the zero-based index of the key in key order in O(log n), or -1 if the key is not in the map.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) IndexOf(key TKey) int {
+	if m.tree.GetNode(key) == nil {
+		return -1
+	}
+	return m.tree.Rank(key)
+}
+
+// CountLess returns the number of keys less than the given key in O(log n).
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) CountLess(key TKey) int {
+	return m.tree.Rank(key)
+}
+
+// CountGreater returns the number of keys greater than the given key in O(log n).
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (m *Map[TKey, TValue]) CountGreater(key TKey) int {
+	count := m.Size() - m.tree.Rank(key)
+	if m.tree.GetNode(key) != nil {
+		count--
+	}
+	return count
+}
+
+// MaxGap finds the largest difference between two consecutive keys of a map with int keys in a single ordered walk.
+// Returns the key after which the gap occurs and the size of the gap.
+// In case of a tie the gap after the smallest key is returned.
+// Third return parameter is false if the map has fewer than two keys.
+func MaxGap[TValue comparable](m *Map[int, TValue]) (afterKey int, gap int, ok bool) {
+	iterator := m.Iterator()
+	if !iterator.Next() {
+		return 0, 0, false
+	}
+	prev := iterator.Key()
+	for iterator.Next() {
+		if diff := iterator.Key() - prev; !ok || diff > gap {
+			afterKey, gap, ok = prev, diff, true
+		}
+		prev = iterator.Key()
+	}
+	return afterKey, gap, ok
+}
+
+// InterpolatedGet searches the value by key in a map with float64 keys and values.
+// If the key is not found, the value is linearly interpolated between the values of its floor and ceiling entries.
+// Second return parameter is false if the key lies outside the range of keys in the map.
+func InterpolatedGet(m *Map[float64, float64], key float64) (value float64, found bool) {
+	floor, foundFloor := m.tree.Floor(key)
+	ceiling, foundCeiling := m.tree.Ceiling(key)
+	switch {
+	case !foundFloor || !foundCeiling:
+		return 0, false
+	case floor == ceiling:
+		return floor.Value, true
+	}
+	ratio := (key - floor.Key) / (ceiling.Key - floor.Key)
+	return floor.Value + ratio*(ceiling.Value-floor.Value), true
+}
+
+// IsValid returns true if the underlying red-black tree satisfies all of its invariants,
+// e.g. to detect corruption caused by external mutation of the tree's exported nodes.
+func (m *Map[TKey, TValue]) IsValid() bool {
+	return m.tree.Validate() == nil
+}
+
+// entryOf returns the key and value of the node if found, otherwise zero values.
+func entryOf[TKey, TValue comparable](node *rbt.Node[TKey, TValue], found bool) (TKey, TValue, bool) {
+	if !found {
+		return *new(TKey), *new(TValue), false
+	}
+	return node.Key, node.Value, true
+}
+
+// eachInRange calls the given function for every element whose key is within [from, to], in key order.
+func (m *Map[TKey, TValue]) eachInRange(from, to TKey, f func(key TKey, value TValue)) {
+	node, found := m.tree.Ceiling(from)
+	if !found {
+		return
+	}
+	for it := m.tree.IteratorAt(node); it.Node() != nil; it.Next() {
+		if m.tree.Comparator(it.Key(), to) > 0 {
+			return
+		}
+		f(it.Key(), it.Value())
+	}
+}
+
 // String returns a string representation of container
 func (m *Map[TKey, TValue]) String() string {
 	str := "TreeMap\nmap["
@@ -149,3 +549,30 @@ func (m *Map[TKey, TValue]) String() string {
 	return strings.TrimRight(str, " ") + "]"
 
 }
+
+// Builds the empty map bottom-up from keys in ascending order, collapsing repeated keys to their last value
+// in place, or falls back to inserting the entries one by one if the keys are not sorted.
+func (m *Map[TKey, TValue]) loadSorted(keys []TKey, values []TValue) {
+	n := 0
+	for i, key := range keys {
+		if n > 0 {
+			compare := m.tree.Comparator(key, keys[n-1])
+			if compare == 0 {
+				values[n-1] = values[i]
+				continue
+			}
+			if compare < 0 {
+				for j := 0; j < n; j++ {
+					m.Put(keys[j], values[j])
+				}
+				for j := i; j < len(keys); j++ {
+					m.Put(keys[j], values[j])
+				}
+				return
+			}
+		}
+		keys[n], values[n] = key, values[i]
+		n++
+	}
+	m.tree.FromSorted(keys[:n], values[:n])
+}
@@ -5,11 +5,13 @@
 package treemap
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/a234567894/gods/maps"
 	"github.com/a234567894/gods/utils"
 )
 
@@ -55,6 +57,30 @@ func TestMapPut(t *testing.T) {
 	}
 }
 
+func TestMapPutWithPreviousAndPutAll(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if old, replaced := m.PutWithPrevious(1, "a"); old != "" || replaced {
+		t.Errorf("Got %v %v expected %v %v", old, replaced, "", false)
+	}
+	if old, replaced := m.PutWithPrevious(1, "b"); old != "a" || !replaced {
+		t.Errorf("Got %v %v expected %v %v", old, replaced, "a", true)
+	}
+	if actualValue, _ := m.Get(1); actualValue != "b" {
+		t.Errorf("Got %v expected %v", actualValue, "b")
+	}
+
+	other := NewWithIntComparator[int, string]()
+	other.Put(2, "c")
+	other.Put(1, "x")
+	m.PutAll(other)
+	if actualValue, expectedValue := fmt.Sprint(m.Keys(), m.Values()), "[1 2] [x c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := other.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestMapMin(t *testing.T) {
 	m := NewWithIntComparator[int, string]()
 
@@ -107,6 +133,28 @@ func TestMapMax(t *testing.T) {
 	}
 }
 
+func TestMapMinMaxEntry(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+
+	if k, v, found := m.MinEntry(); k != 0 || v != "" || found {
+		t.Errorf("Got %v->%v %v expected %v->%v %v", k, v, found, 0, "", false)
+	}
+	if k, v, found := m.MaxEntry(); k != 0 || v != "" || found {
+		t.Errorf("Got %v->%v %v expected %v->%v %v", k, v, found, 0, "", false)
+	}
+
+	m.Put(5, "e")
+	m.Put(1, "a")
+	m.Put(7, "g")
+
+	if k, v, found := m.MinEntry(); k != 1 || v != "a" || !found {
+		t.Errorf("Got %v->%v %v expected %v->%v %v", k, v, found, 1, "a", true)
+	}
+	if k, v, found := m.MaxEntry(); k != 7 || v != "g" || !found {
+		t.Errorf("Got %v->%v %v expected %v->%v %v", k, v, found, 7, "g", true)
+	}
+}
+
 func TestMapClear(t *testing.T) {
 	m := NewWithIntComparator[int, string]()
 	m.Put(5, "e")
@@ -122,6 +170,24 @@ func TestMapClear(t *testing.T) {
 	}
 }
 
+func TestMapModify(t *testing.T) {
+	type account struct {
+		balance int
+		history [8]int
+	}
+	m := NewWithStringComparator[string, account]()
+	m.Put("a", account{balance: 10})
+	if found := m.Modify("a", func(value *account) { value.balance += 5 }); !found {
+		t.Errorf("Got %v expected %v", found, true)
+	}
+	if actualValue, _ := m.Get("a"); actualValue.balance != 15 {
+		t.Errorf("Got %v expected %v", actualValue.balance, 15)
+	}
+	if found := m.Modify("b", func(value *account) { t.Errorf("Should not be called for a missing key") }); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+}
+
 func TestMapRemove(t *testing.T) {
 	m := NewWithIntComparator[int, string]()
 	m.Put(5, "e")
@@ -264,6 +330,533 @@ func sameElements[T comparable](a []T, b []T) bool {
 	return true
 }
 
+func TestMapMaxGap(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if _, _, ok := MaxGap(m); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	m.Put(5, "e")
+	if _, _, ok := MaxGap(m); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	// uniform spacing, tie resolves to the smallest key
+	m.Put(10, "j")
+	m.Put(15, "o")
+	m.Put(20, "t")
+	if afterKey, gap, ok := MaxGap(m); afterKey != 5 || gap != 5 || !ok {
+		t.Errorf("Got %v %v %v expected %v %v %v", afterKey, gap, ok, 5, 5, true)
+	}
+
+	// one large gap
+	m.Put(100, "x")
+	m.Put(105, "y")
+	if afterKey, gap, ok := MaxGap(m); afterKey != 20 || gap != 80 || !ok {
+		t.Errorf("Got %v %v %v expected %v %v %v", afterKey, gap, ok, 20, 80, true)
+	}
+}
+
+func TestMapSplit(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	for i := 1; i <= 5; i++ {
+		m.Put(i*10, fmt.Sprint(i))
+	}
+	tests := [][]interface{}{
+		{30, "[10 20]", "[30 40 50]"},
+		{25, "[10 20]", "[30 40 50]"},
+		{5, "[]", "[10 20 30 40 50]"},
+		{60, "[10 20 30 40 50]", "[]"},
+	}
+	for _, test := range tests {
+		left, right := m.Split(test[0].(int))
+		if actualValue, expectedValue := fmt.Sprint(left.Keys()), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(right.Keys()), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue := left.IsValid() && right.IsValid(); actualValue != true {
+			t.Errorf("Got %v expected %v", actualValue, true)
+		}
+	}
+	if actualValue, expectedValue := m.Size(), 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	left, _ := m.Split(30)
+	left.Put(15, "x")
+	if _, found := m.Get(15); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+}
+
+func TestMapSplitN(t *testing.T) {
+	m := NewWithIntComparator[int, int]()
+	for i := 0; i < 10; i++ {
+		m.Put(i, i*i)
+	}
+
+	tests := [][]interface{}{
+		{1, "[10]"},
+		{3, "[4 3 3]"},
+		{4, "[3 3 2 2]"},
+		{10, "[1 1 1 1 1 1 1 1 1 1]"},
+		{12, "[1 1 1 1 1 1 1 1 1 1 0 0]"},
+		{0, "[10]"},
+	}
+	for _, test := range tests {
+		chunks := m.SplitN(test[0].(int))
+		sizes := []int{}
+		keys := []int{}
+		for _, chunk := range chunks {
+			sizes = append(sizes, chunk.Size())
+			keys = append(keys, chunk.Keys()...)
+			for _, key := range chunk.Keys() {
+				if actualValue, found := chunk.Get(key); actualValue != key*key || !found {
+					t.Errorf("Got %v expected %v", actualValue, key*key)
+				}
+			}
+		}
+		if actualValue, expectedValue := fmt.Sprintf("%v", sizes), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprintf("%v", keys), fmt.Sprintf("%v", m.Keys()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMapInterpolatedGet(t *testing.T) {
+	m := NewWith[float64, float64](utils.Float64Comparator)
+	if actualValue, found := InterpolatedGet(m, 1.0); actualValue != 0 || found {
+		t.Errorf("Got %v %v expected %v %v", actualValue, found, 0, false)
+	}
+
+	m.Put(0.0, 10.0)
+	m.Put(10.0, 20.0)
+	m.Put(20.0, 0.0)
+
+	tests := [][]interface{}{
+		{0.0, 10.0, true},
+		{10.0, 20.0, true},
+		{5.0, 15.0, true},
+		{2.5, 12.5, true},
+		{15.0, 10.0, true},
+		{-1.0, 0.0, false},
+		{20.5, 0.0, false},
+	}
+	for _, test := range tests {
+		actualValue, found := InterpolatedGet(m, test[0].(float64))
+		if actualValue != test[1] || found != test[2] {
+			t.Errorf("Got %v %v expected %v %v", actualValue, found, test[1], test[2])
+		}
+	}
+}
+
+func TestMapPageFrom(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if actualValue := m.PageFrom(1, 2); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+
+	for i := 1; i <= 9; i += 2 {
+		m.Put(i, fmt.Sprintf("%d", i))
+	}
+
+	tests := [][]interface{}{
+		{3, 2, "[{3 3} {5 5}]"},        // cursor exists
+		{4, 2, "[{5 5} {7 7}]"},        // cursor missing
+		{-5, 3, "[{1 1} {3 3} {5 5}]"}, // cursor before the first key
+		{7, 10, "[{7 7} {9 9}]"},       // limit exceeds remaining entries
+		{10, 2, "[]"},                  // cursor after the last key
+		{1, 0, "[]"},                   // no limit
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := fmt.Sprintf("%v", m.PageFrom(test[0].(int), test[1].(int))), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	// cursor was deleted
+	m.Remove(5)
+	if actualValue, expectedValue := fmt.Sprintf("%v", m.PageFrom(5, 2)), "[{7 7} {9 9}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIsValid(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if actualValue := m.IsValid(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	for i := 0; i < 20; i++ {
+		m.Put(i, fmt.Sprintf("%d", i))
+	}
+	if actualValue := m.IsValid(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	m.tree.Root.Key = 100 // corrupt the ordering
+	if actualValue := m.IsValid(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestMapReverseComparator(t *testing.T) {
+	m := NewWith[int, string](utils.Reverse(utils.IntComparator))
+	m.Put(1, "a")
+	m.Put(3, "c")
+	m.Put(2, "b")
+	if actualValue, expectedValue := fmt.Sprintf("%v", m.Keys()), "[3 2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", m.Values()), "[c b a]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapLoadFromChannel(t *testing.T) {
+	send := func(keys ...int) <-chan maps.Entry[int, string] {
+		ch := make(chan maps.Entry[int, string], len(keys))
+		for _, key := range keys {
+			ch <- maps.Entry[int, string]{Key: key, Value: fmt.Sprintf("v%d", key)}
+		}
+		close(ch)
+		return ch
+	}
+
+	tests := [][]interface{}{
+		{send(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), true, "[1 2 3 4 5 6 7 8 9 10]"},
+		{send(1, 2, 2, 3), true, "[1 2 3]"},
+		{send(5, 3, 9, 1, 7), true, "[1 3 5 7 9]"},
+		{send(5, 3, 9, 1, 7), false, "[1 3 5 7 9]"},
+		{send(), true, "[]"},
+	}
+	for _, test := range tests {
+		m := NewWithIntComparator[int, string]()
+		m.LoadFromChannel(test[0].(<-chan maps.Entry[int, string]), test[1].(bool))
+		if actualValue, expectedValue := fmt.Sprint(m.Keys()), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for _, key := range m.Keys() {
+			if actualValue, found := m.Get(key); actualValue != fmt.Sprintf("v%d", key) || !found {
+				t.Errorf("Got %v expected %v", actualValue, fmt.Sprintf("v%d", key))
+			}
+		}
+		if actualValue := m.IsValid(); actualValue != true {
+			t.Errorf("Got %v expected %v", actualValue, true)
+		}
+	}
+
+	// loading into a non-empty map keeps existing entries
+	m := NewWithIntComparator[int, string]()
+	m.Put(4, "v4")
+	m.LoadFromChannel(send(1, 2, 3), true)
+	if actualValue, expectedValue := fmt.Sprint(m.Keys()), "[1 2 3 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapNewFromMap(t *testing.T) {
+	m := NewFromMap(utils.StringComparator, map[string]int{"c": 3, "a": 1, "d": 4, "b": 2})
+	if actualValue, expectedValue := fmt.Sprint(m.Keys(), m.Values()), "[a b c d] [1 2 3 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := m.IsValid(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := NewFromMap[string, int](utils.StringComparator, nil); !actualValue.Empty() {
+		t.Errorf("Got %v expected %v", actualValue, "empty map")
+	}
+}
+
+func TestMapNewFromSortedSlices(t *testing.T) {
+	tests := [][]interface{}{
+		{[]int{1, 2, 3, 4, 5}, []string{"a", "b", "c", "d", "e"}, "[1 2 3 4 5] [a b c d e]"},
+		{[]int{1, 2, 2, 3}, []string{"a", "b", "x", "c"}, "[1 2 3] [a x c]"},
+		{[]int{1, 1, 3, 2, 3}, []string{"a", "b", "c", "d", "e"}, "[1 2 3] [b d e]"},
+		{[]int{}, []string{}, "[] []"},
+	}
+	for _, test := range tests {
+		keys, values := test[0].([]int), test[1].([]string)
+		m, err := NewFromSortedSlices(utils.IntComparator, keys, values)
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(m.Keys(), m.Values()), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue := m.IsValid(); actualValue != true {
+			t.Errorf("Got %v expected %v", actualValue, true)
+		}
+	}
+
+	keys, values := []int{1, 1, 2}, []string{"a", "b", "c"}
+	if _, err := NewFromSortedSlices(utils.IntComparator, keys, values); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys, values), "[1 1 2] [a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, err := NewFromSortedSlices(utils.IntComparator, []int{1, 2}, []string{"a"}); err == nil {
+		t.Errorf("Got no error for slices of different lengths")
+	}
+}
+
+func TestMapRange(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if actualValue := m.RangeKeys(0, 10); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	for i := 1; i <= 9; i += 2 {
+		m.Put(i, fmt.Sprint(i))
+	}
+	tests := [][]interface{}{
+		{3, 7, "[3 5 7]", 3},
+		{2, 8, "[3 5 7]", 3},
+		{0, 100, "[1 3 5 7 9]", 5},
+		{4, 4, "[]", 0},
+		{5, 5, "[5]", 1},
+		{7, 3, "[]", 0},
+		{10, 20, "[]", 0},
+	}
+	for _, test := range tests {
+		from, to := test[0].(int), test[1].(int)
+		if actualValue, expectedValue := fmt.Sprint(m.RangeKeys(from, to)), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(m.RangeValues(from, to)), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := m.CountRange(from, to), test[3]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMapQuantile(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if _, _, ok := m.Quantile(0.5); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	for _, key := range []int{50, 10, 40, 20, 30} {
+		m.Put(key, fmt.Sprint(key))
+	}
+	tests := [][]interface{}{
+		{0.0, 10},
+		{1.0, 50},
+		{0.5, 30},
+		{0.25, 20},
+		{0.8, 40},
+		{0.99, 40},
+		{-1.0, 10},
+		{2.0, 50},
+	}
+	for _, test := range tests {
+		key, value, ok := m.Quantile(test[0].(float64))
+		if !ok || key != test[1] || value != fmt.Sprint(test[1]) {
+			t.Errorf("Got %v %v %v expected %v", key, value, ok, test[1])
+		}
+	}
+
+	m.Put(60, "60")
+	if key, _, _ := m.Quantile(0.5); key != 30 {
+		t.Errorf("Got %v expected %v", key, 30)
+	}
+}
+
+func TestMapKeyAtAndIndexOf(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if key, found := m.KeyAt(0); key != 0 || found {
+		t.Errorf("Got %v %v expected %v %v", key, found, 0, false)
+	}
+	for _, key := range []int{30, 10, 50, 20, 40} {
+		m.Put(key, fmt.Sprint(key))
+	}
+	m.Remove(50)
+	for index, expectedKey := range []int{10, 20, 30, 40} {
+		if key, found := m.KeyAt(index); key != expectedKey || !found {
+			t.Errorf("Got %v %v expected %v %v", key, found, expectedKey, true)
+		}
+		if actualValue := m.IndexOf(expectedKey); actualValue != index {
+			t.Errorf("Got %v expected %v", actualValue, index)
+		}
+	}
+	if key, found := m.KeyAt(4); key != 0 || found {
+		t.Errorf("Got %v %v expected %v %v", key, found, 0, false)
+	}
+	if actualValue := m.IndexOf(25); actualValue != -1 {
+		t.Errorf("Got %v expected %v", actualValue, -1)
+	}
+}
+
+func TestMapCountLessAndGreater(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if actualValue, expectedValue := m.CountLess(1)+m.CountGreater(1), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 1; i <= 5; i++ {
+		m.Put(i*10, "")
+	}
+	tests := [][]interface{}{
+		{5, 0, 5},
+		{10, 0, 4},
+		{25, 2, 3},
+		{30, 2, 2},
+		{50, 4, 0},
+		{60, 5, 0},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := m.CountLess(test[0].(int)), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := m.CountGreater(test[0].(int)), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMapDescending(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if actualValue := len(m.KeysDescending()); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := len(m.ValuesDescending()); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if it := m.ReverseIterator(); it.Prev() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+
+	m.Put(2, "b")
+	m.Put(3, "c")
+	m.Put(1, "a")
+	if actualValue, expectedValue := fmt.Sprint(m.KeysDescending()), "[3 2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.ValuesDescending()), "[c b a]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	it := m.ReverseIterator()
+	keys := ""
+	for it.Prev() {
+		keys += fmt.Sprint(it.Key())
+	}
+	if actualValue, expectedValue := keys, "321"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if it.Next(); it.Key() != 1 {
+		t.Errorf("Got %v expected %v", it.Key(), 1)
+	}
+}
+
+func TestMapEntrySet(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if actualValue := m.EntrySet(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	m.Put(3, "c")
+	m.Put(1, "a")
+	m.Put(2, "b")
+	entries := m.EntrySet()
+	if actualValue, expectedValue := fmt.Sprint(entries), "[{1 a} {2 b} {3 c}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	entries[0].Value = "x"
+	if actualValue, _ := m.Get(1); actualValue != "a" {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+}
+
+func TestMapNavigableEntries(t *testing.T) {
+	m := NewWithIntComparator[int, int]()
+	if _, _, found := m.FloorEntry(0); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+	m.Put(0, 0)
+	m.Put(2, 20)
+	m.Put(4, 40)
+
+	// key, floor, ceiling, higher, lower (-1 if not found)
+	tests := [][]int{
+		{-1, -1, 0, 0, -1},
+		{0, 0, 0, 2, -1},
+		{1, 0, 2, 2, 0},
+		{2, 2, 2, 4, 0},
+		{4, 4, 4, -1, 2},
+		{5, 4, -1, -1, 4},
+	}
+	asInt := func(key, value int, found bool) int {
+		if !found {
+			return -1
+		}
+		if value != key*10 {
+			t.Errorf("Got %v expected %v", value, key*10)
+		}
+		return key
+	}
+	for _, test := range tests {
+		if actualValue := asInt(m.FloorEntry(test[0])); actualValue != test[1] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+		if actualValue := asInt(m.CeilingEntry(test[0])); actualValue != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[2])
+		}
+		if actualValue := asInt(m.HigherEntry(test[0])); actualValue != test[3] {
+			t.Errorf("Got %v expected %v", actualValue, test[3])
+		}
+		if actualValue := asInt(m.LowerEntry(test[0])); actualValue != test[4] {
+			t.Errorf("Got %v expected %v", actualValue, test[4])
+		}
+	}
+	if key, found := m.HigherKey(2); key != 4 || !found {
+		t.Errorf("Got %v %v expected %v %v", key, found, 4, true)
+	}
+	if key, found := m.LowerKey(2); key != 0 || !found {
+		t.Errorf("Got %v %v expected %v %v", key, found, 0, true)
+	}
+	if key, found := m.LowerKey(0); key != 0 || found {
+		t.Errorf("Got %v %v expected %v %v", key, found, 0, false)
+	}
+}
+
+func TestMapReversed(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	if actualValue := m.Reversed().Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	m.Put(2, "b")
+	m.Put(1, "a")
+	m.Put(4, "d")
+	m.Put(3, "c")
+
+	reversed := m.Reversed()
+	if actualValue, expectedValue := fmt.Sprint(reversed.Keys()), "[4 3 2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(reversed.Values()), "[d c b a]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := reversed.IsValid(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	// reversed map is independent and keeps descending order on insertion
+	reversed.Put(5, "e")
+	reversed.Remove(1)
+	if actualValue, expectedValue := fmt.Sprint(reversed.Keys()), "[5 4 3 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.Keys()), "[1 2 3 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(reversed.Reversed().Keys()), "[2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestMapEach(t *testing.T) {
 	m := NewWithStringComparator[string, int]()
 	m.Put("c", 3)
@@ -316,6 +909,42 @@ func TestMapMap(t *testing.T) {
 	}
 }
 
+func TestMapRejectAndPartition(t *testing.T) {
+	m := NewWithStringComparator[string, int]()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("d", 4)
+	odd := func(key string, value int) bool {
+		return value%2 == 1
+	}
+
+	rejected := m.Reject(odd)
+	if actualValue, expectedValue := fmt.Sprint(rejected.Keys()), "[b d]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	matching, rest := m.Partition(odd)
+	if actualValue, expectedValue := fmt.Sprint(matching.Keys(), matching.Values()), "[a c] [1 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(rest.Keys(), rest.Values()), "[b d] [2 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if !matching.IsValid() || !rest.IsValid() {
+		t.Errorf("Partition produced an invalid tree")
+	}
+	rest.Put("aa", 0)
+	if actualValue, expectedValue := fmt.Sprint(rest.Keys()), "[aa b d]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	none, all := m.Partition(func(key string, value int) bool { return false })
+	if actualValue, expectedValue := fmt.Sprint(none.Size(), all.Size()), "0 4"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestMapSelect(t *testing.T) {
 	m := NewWithStringComparator[string, int]()
 	m.Put("c", 3)
@@ -416,6 +1045,44 @@ func TestMapChaining(t *testing.T) {
 	}
 }
 
+func TestMapScanValues(t *testing.T) {
+	m := NewWithIntComparator[int, int]()
+	m.ScanValues(0, func(acc, value int) int { return acc + value }, func(key int, runningAcc int) {
+		t.Errorf("Should not emit on empty map")
+	})
+
+	m.Put(3, 30)
+	m.Put(1, 10)
+	m.Put(2, 20)
+	m.Put(4, 40)
+
+	keys := []int{}
+	totals := []int{}
+	m.ScanValues(0, func(acc, value int) int { return acc + value }, func(key int, runningAcc int) {
+		keys = append(keys, key)
+		totals = append(totals, runningAcc)
+	})
+	if actualValue, expectedValue := fmt.Sprintf("%v", keys), "[1 2 3 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", totals), "[10 30 60 100]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIteratorWithKey(t *testing.T) {
+	var m maps.IteratorMap[int, string] = NewWithIntComparator[int, string]()
+	m.Put(2, "b")
+	m.Put(1, "a")
+	keys := ""
+	for it := m.IteratorWithKey(); it.Next(); {
+		keys += fmt.Sprint(it.Key(), it.Value())
+	}
+	if actualValue, expectedValue := keys, "1a2b"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestMapIteratorNextOnEmpty(t *testing.T) {
 	m := NewWithStringComparator[int, string]()
 	it := m.Iterator()
@@ -714,6 +1381,52 @@ func TestMapSerialization(t *testing.T) {
 	}
 }
 
+func TestMapStreamJSON(t *testing.T) {
+	m := NewWithIntComparator[int, string]()
+	for i := 20; i > 0; i-- {
+		m.Put(i, fmt.Sprint("v", i))
+	}
+	var buf bytes.Buffer
+	if err := m.WriteJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	// the members are written in key order rather than in the order of their JSON strings
+	if actualValue, expectedValue := buf.String(), `{"1":"v1","2":"v2","3":"v3",`; !strings.HasPrefix(actualValue, expectedValue) {
+		t.Errorf("Got %v expected prefix %v", actualValue, expectedValue)
+	}
+	if !json.Valid(buf.Bytes()) {
+		t.Errorf("Got invalid JSON %v", buf.String())
+	}
+
+	other := NewWithIntComparator[int, string]()
+	other.Put(100, "stale")
+	if err := other.ReadJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys(), other.Values()), fmt.Sprint(m.Keys(), m.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	empty := NewWithIntComparator[int, string]()
+	buf.Reset()
+	if err := empty.WriteJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := buf.String(), "{}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if err := other.ReadJSON(strings.NewReader(`"a"`)); err == nil {
+		t.Errorf("Got no error for a non-object input")
+	}
+	if err := other.ReadJSON(strings.NewReader(`{"1":"a","2":`)); err == nil {
+		t.Errorf("Got no error for a truncated input")
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys()), "[1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestMapString(t *testing.T) {
 	c := NewWithStringComparator[string, int]()
 	c.Put("a", 1)
@@ -56,6 +56,19 @@ func (t *Tree[TKey, TValue]) Put(key TKey, value TValue) {
 	t.put(key, value, nil, &t.Root)
 }
 
+// PutWithPrevious inserts node into the tree like Put and returns the value it replaced.
+// Second return parameter is true if an existing value was replaced, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (t *Tree[TKey, TValue]) PutWithPrevious(key TKey, value TValue) (old TValue, replaced bool) {
+	if n := t.GetNode(key); n != nil {
+		old = n.Value
+		n.Key, n.Value = key, value
+		return old, true
+	}
+	t.put(key, value, nil, &t.Root)
+	return old, false
+}
+
 // Get searches the node in the tree by key and returns its value or nil if key is not found in tree.
 // Second return parameter is true if key was found, otherwise false.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
@@ -67,6 +80,12 @@ func (t *Tree[TKey, TValue]) Get(key TKey) (value TValue, found bool) {
 	return *new(TValue), false
 }
 
+// Contains returns true if the key is in the tree, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (t *Tree[TKey, TValue]) Contains(key TKey) bool {
+	return t.GetNode(key) != nil
+}
+
 // GetNode searches the node in the tree by key and returns its node or nil if key is not found in tree.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (t *Tree[TKey, TValue]) GetNode(key TKey) *Node[TKey, TValue] {
@@ -91,6 +110,22 @@ func (t *Tree[TKey, TValue]) Remove(key TKey) {
 	t.remove(key, &t.Root)
 }
 
+// RemoveRange removes all nodes whose keys k satisfy from <= k <= to and returns the number of removed nodes.
+// The keys in the range are collected in a single in-order walk starting at the ceiling of from, then removed
+// one by one with the usual rebalancing, so the tree stays balanced and the purge takes O(k log n).
+// Keys should adhere to the comparator's type assertion, otherwise method panics.
+func (t *Tree[TKey, TValue]) RemoveRange(from, to TKey) int {
+	var keys []TKey
+	node, _ := t.Ceiling(from)
+	for ; node != nil && t.Comparator(node.Key, to) <= 0; node = node.Next() {
+		keys = append(keys, node.Key)
+	}
+	for _, key := range keys {
+		t.remove(key, &t.Root)
+	}
+	return len(keys)
+}
+
 // Empty returns true if tree does not contain any nodes.
 func (t *Tree[TKey, TValue]) Empty() bool {
 	return t.size == 0
@@ -117,6 +152,37 @@ func (n *Node[TKey, TValue]) Size() int {
 	return size
 }
 
+// BalanceFactor returns the balance factor of the node, i.e. the height of its right subtree
+// minus the height of its left subtree, as maintained by the tree during insertion and removal.
+func (n *Node[TKey, TValue]) BalanceFactor() int {
+	if n == nil {
+		return 0
+	}
+	return int(n.b)
+}
+
+// Height returns the height of the subtree rooted at the node (a single node has height 1, nil has height 0).
+// Follows the heavier side according to the maintained balance factors, so it runs in O(log n).
+func (n *Node[TKey, TValue]) Height() int {
+	height := 0
+	for ; n != nil; height++ {
+		if n.b < 0 {
+			n = n.Children[0]
+		} else {
+			n = n.Children[1]
+		}
+	}
+	return height
+}
+
+// Validate checks the AVL invariants of the tree by a full traversal, i.e. that every maintained balance factor
+// equals the actual difference in height of the node's subtrees and lies within [-1,1].
+// Returns an error describing the first violating node, otherwise nil.
+func (t *Tree[TKey, TValue]) Validate() error {
+	_, err := validate(t.Root)
+	return err
+}
+
 // Keys returns all keys in-order
 func (t *Tree[TKey, TValue]) Keys() []TKey {
 	keys := make([]TKey, t.size)
@@ -149,6 +215,24 @@ func (t *Tree[TKey, TValue]) Right() *Node[TKey, TValue] {
 	return t.bottom(1)
 }
 
+// MinKey returns the minimum key of the AVL tree.
+// Second return parameter is false if the tree is empty.
+func (t *Tree[TKey, TValue]) MinKey() (key TKey, found bool) {
+	if n := t.Left(); n != nil {
+		return n.Key, true
+	}
+	return *new(TKey), false
+}
+
+// MaxKey returns the maximum key of the AVL tree.
+// Second return parameter is false if the tree is empty.
+func (t *Tree[TKey, TValue]) MaxKey() (key TKey, found bool) {
+	if n := t.Right(); n != nil {
+		return n.Key, true
+	}
+	return *new(TKey), false
+}
+
 // Floor Finds floor node of the input key, return the floor node or nil if no floor is found.
 // Second return parameter is true if floor was found, otherwise false.
 //
@@ -186,7 +270,7 @@ func (t *Tree[TKey, TValue]) Floor(key TKey) (floor *Node[TKey, TValue], found b
 // all nodes in the tree is smaller than the given node.
 //
 // Key should adhere to the comparator's type assertion, otherwise method panics.
-func (t *Tree[TKey, TValue]) Ceiling(key TKey) (floor *Node[TKey, TValue], found bool) {
+func (t *Tree[TKey, TValue]) Ceiling(key TKey) (ceiling *Node[TKey, TValue], found bool) {
 	found = false
 	n := t.Root
 	for n != nil {
@@ -195,7 +279,7 @@ func (t *Tree[TKey, TValue]) Ceiling(key TKey) (floor *Node[TKey, TValue], found
 		case c == 0:
 			return n, true
 		case c < 0:
-			floor, found = n, true
+			ceiling, found = n, true
 			n = n.Children[0]
 		case c > 0:
 			n = n.Children[1]
@@ -213,6 +297,17 @@ func (t *Tree[TKey, TValue]) Clear() {
 	t.size = 0
 }
 
+// Clone returns an independent copy of the tree with the same comparator and shape.
+// All nodes are copied along with their balance factors, so the copy is balanced just like the tree
+// and either of them can be modified without affecting the other. Keys and values are copied shallowly.
+func (t *Tree[TKey, TValue]) Clone() *Tree[TKey, TValue] {
+	clone := &Tree[TKey, TValue]{Comparator: t.Comparator, size: t.size}
+	if t.Root != nil {
+		clone.Root = copyNode(t.Root, nil)
+	}
+	return clone
+}
+
 // String returns a string representation of container
 func (t *Tree[TKey, TValue]) String() string {
 	str := "AVLTree\n"
@@ -226,6 +321,27 @@ func (n *Node[TKey, TValue]) String() string {
 	return fmt.Sprintf("%v", n.Key)
 }
 
+func validate[TKey, TValue comparable](n *Node[TKey, TValue]) (height int, err error) {
+	if n == nil {
+		return 0, nil
+	}
+	left, err := validate(n.Children[0])
+	if err != nil {
+		return 0, err
+	}
+	right, err := validate(n.Children[1])
+	if err != nil {
+		return 0, err
+	}
+	if b := right - left; b < -1 || b > 1 || b != int(n.b) {
+		return 0, fmt.Errorf("node %v has balance %d, maintained balance factor is %d", n.Key, b, n.b)
+	}
+	if left > right {
+		return left + 1, nil
+	}
+	return right + 1, nil
+}
+
 func (t *Tree[TKey, TValue]) put(key TKey, value TValue, p *Node[TKey, TValue], qp **Node[TKey, TValue]) bool {
 	q := *qp
 	if q == nil {
@@ -473,3 +589,14 @@ func output[TKey, TValue comparable](node *Node[TKey, TValue], prefix string, is
 		output(node.Children[0], newPrefix, true, str)
 	}
 }
+
+// copyNode copies the subtree rooted at node and links the copy to the given parent.
+func copyNode[TKey, TValue comparable](node *Node[TKey, TValue], parent *Node[TKey, TValue]) *Node[TKey, TValue] {
+	copied := &Node[TKey, TValue]{Key: node.Key, Value: node.Value, Parent: parent, b: node.b}
+	for i, child := range node.Children {
+		if child != nil {
+			copied.Children[i] = copyNode(child, copied)
+		}
+	}
+	return copied
+}
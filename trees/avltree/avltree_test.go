@@ -4,6 +4,7 @@
 package avltree
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -97,6 +98,36 @@ func TestAVLTreePut(t *testing.T) {
 	}
 }
 
+func TestAVLTreePutWithPreviousAndContains(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+	if actualValue := tree.Contains(1); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	for i := 1; i <= 10; i++ {
+		if old, replaced := tree.PutWithPrevious(i, fmt.Sprint(i)); old != "" || replaced {
+			t.Errorf("Got %v %v expected %v %v", old, replaced, "", false)
+		}
+	}
+	if old, replaced := tree.PutWithPrevious(5, "x"); old != "5" || !replaced {
+		t.Errorf("Got %v %v expected %v %v", old, replaced, "5", true)
+	}
+	if actualValue, expectedValue := tree.Size(), 10; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, _ := tree.Get(5); actualValue != "x" {
+		t.Errorf("Got %v expected %v", actualValue, "x")
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := tree.Contains(10), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Contains(11), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestAVLTreeRemove(t *testing.T) {
 	tree := NewWithIntComparator[int, string]()
 	tree.Put(5, "e")
@@ -198,6 +229,29 @@ func TestAVLTreeLeftAndRight(t *testing.T) {
 	}
 }
 
+func TestAVLTreeMinKeyAndMaxKey(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+
+	if key, found := tree.MinKey(); key != 0 || found {
+		t.Errorf("Got %v %v expected %v %v", key, found, 0, false)
+	}
+	if key, found := tree.MaxKey(); key != 0 || found {
+		t.Errorf("Got %v %v expected %v %v", key, found, 0, false)
+	}
+
+	tree.Put(5, "e")
+	tree.Put(3, "c")
+	tree.Put(9, "i")
+	tree.Put(1, "a")
+
+	if key, found := tree.MinKey(); key != 1 || !found {
+		t.Errorf("Got %v %v expected %v %v", key, found, 1, true)
+	}
+	if key, found := tree.MaxKey(); key != 9 || !found {
+		t.Errorf("Got %v %v expected %v %v", key, found, 9, true)
+	}
+}
+
 func TestAVLTreeCeilingAndFloor(t *testing.T) {
 	tree := NewWithIntComparator[int, string]()
 
@@ -231,6 +285,139 @@ func TestAVLTreeCeilingAndFloor(t *testing.T) {
 	}
 }
 
+func TestAVLTreeRemoveRange(t *testing.T) {
+	tree := NewWithIntComparator[int, int]()
+	if actualValue := tree.RemoveRange(0, 10); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	for i := 0; i < 1000; i++ {
+		tree.Put(i, i)
+	}
+
+	if actualValue, expectedValue := tree.RemoveRange(-10, 599), 600; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := tree.RemoveRange(700, 700), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.RemoveRange(900, 2000), 100; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.RemoveRange(800, 700), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := tree.Size(), 299; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if key, _ := tree.MinKey(); key != 600 {
+		t.Errorf("Got %v expected %v", key, 600)
+	}
+	if key, _ := tree.MaxKey(); key != 899 {
+		t.Errorf("Got %v expected %v", key, 899)
+	}
+	if _, found := tree.Get(700); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+}
+
+func TestAVLTreeBalanceFactorAndHeight(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+	if actualValue, expectedValue := tree.Root.Height(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Root.BalanceFactor(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+	if actualValue, expectedValue := tree.Root.Height(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Root.BalanceFactor(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Put(3, "c") // rotation
+	if actualValue, expectedValue := tree.Root.Key, 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Root.BalanceFactor(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for i := 4; i <= 100; i++ {
+		tree.Put(i, fmt.Sprintf("%d", i))
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	if actualValue, expectedValue := tree.Root.Height(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 1; i <= 100; i += 3 {
+		tree.Remove(i)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	tree.Root.b = 2 // tamper
+	if err := tree.Validate(); err == nil {
+		t.Errorf("Expected error on invalid balance factor")
+	}
+}
+
+func TestAVLTreeClone(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+	if actualValue := tree.Clone(); !actualValue.Empty() || actualValue.Root != nil {
+		t.Errorf("Got %v expected %v", actualValue, "empty tree")
+	}
+	for i := 1; i <= 50; i++ {
+		tree.Put(i, fmt.Sprintf("%d", i))
+	}
+	clone := tree.Clone()
+	if actualValue, expectedValue := clone.String(), tree.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := clone.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	expected := tree.String()
+	for i := 1; i <= 50; i += 2 {
+		clone.Remove(i)
+	}
+	for i := 51; i <= 100; i++ {
+		clone.Put(i, fmt.Sprintf("%d", i))
+	}
+	clone.Put(2, "two")
+	if err := clone.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := clone.Size(), 75; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.String(), expected; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Size(), 50; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, _ := tree.Get(2); actualValue != "2" {
+		t.Errorf("Got %v expected %v", actualValue, "2")
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+}
+
 func TestAVLTreeIteratorNextOnEmpty(t *testing.T) {
 	tree := NewWithIntComparator[int, string]()
 	it := tree.Iterator()
@@ -655,6 +842,57 @@ func TestAVLTreeIteratorPrevTo(t *testing.T) {
 	}
 }
 
+func TestAVLTreeIteratorSeek(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+	if _, actualValue := tree.Seek(1); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	for i := 2; i <= 20; i += 2 {
+		tree.Put(i, fmt.Sprintf("%d", i))
+	}
+
+	// exact hit
+	it, actualValue := tree.Seek(8)
+	if actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if key, value := it.Key(), it.Value(); key != 8 || value != "8" {
+		t.Errorf("Got %v,%v expected %v,%v", key, value, 8, "8")
+	}
+
+	// missing key seeks to the next larger one and iteration continues from there
+	if actualValue := it.Seek(13); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	keys := []int{it.Key()}
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", keys), "[14 16 18 20]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// seek before the first element
+	if actualValue := it.Seek(0); actualValue != true || it.Key() != 2 {
+		t.Errorf("Got %v expected %v", it.Key(), 2)
+	}
+	if actualValue := it.Prev(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	// seek past the end leaves the iterator at the end
+	if actualValue := it.Seek(21); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := it.Next(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := it.Prev(); actualValue != true || it.Key() != 20 {
+		t.Errorf("Got %v expected %v", it.Key(), 20)
+	}
+}
+
 func TestAVLTreeSerialization(t *testing.T) {
 	tree := NewWith[string, string](utils.StringComparator)
 	tree = NewWithStringComparator[string, string]()
@@ -697,6 +935,98 @@ func TestAVLTreeSerialization(t *testing.T) {
 	}
 }
 
+func TestAVLTreeSerializationOrdered(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+	tree.Put(10, "c")
+	tree.Put(2, "b")
+	tree.Put(1, "a")
+
+	bytes, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(bytes), `[{"key":1,"value":"a"},{"key":2,"value":"b"},{"key":10,"value":"c"}]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	deserialized := NewWithIntComparator[int, string]()
+	deserialized.Put(5, "stale")
+	if err := deserialized.FromJSON(bytes); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := deserialized.String(), tree.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// float keys that would collide once stringified stay distinct
+	floats := NewWith[float64, int](utils.Float64Comparator)
+	floats.Put(0.1, 1)
+	floats.Put(0.1000000000000001, 2)
+	bytes, _ = floats.ToJSON()
+	floats.Clear()
+	if err := floats.FromJSON(bytes); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := floats.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if err := deserialized.FromJSON([]byte(`[{"key":1,"value":"a"`)); err == nil {
+		t.Errorf("Expected error on malformed input")
+	}
+}
+
+func TestAVLTreeStreamJSON(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+	for i := 20; i > 0; i-- {
+		tree.Put(i, fmt.Sprint("v", i))
+	}
+	var buf bytes.Buffer
+	if err := tree.WriteJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	data, _ := tree.ToJSON()
+	if actualValue, expectedValue := buf.String(), string(data); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	other := NewWithIntComparator[int, string]()
+	other.Put(100, "stale")
+	if err := other.ReadJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys(), other.Values()), fmt.Sprint(tree.Keys(), tree.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	empty := NewWithIntComparator[int, string]()
+	buf.Reset()
+	if err := empty.WriteJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := buf.String(), "[]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// the object form accepted by FromJSON is read in input order
+	if err := other.ReadJSON(strings.NewReader(`{"2":"b","1":"a"}`)); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys(), other.Values()), "[1 2] [a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if err := other.ReadJSON(strings.NewReader(`"a"`)); err == nil {
+		t.Errorf("Got no error for a non-array input")
+	}
+	if err := other.ReadJSON(strings.NewReader(`[{"key":1,"value":"a"},{"key":`)); err == nil {
+		t.Errorf("Got no error for a truncated input")
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys()), "[1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestAVLTreeString(t *testing.T) {
 	c := NewWithIntComparator[int, int]()
 	c.Put(1, 1)
@@ -27,6 +27,15 @@ func (tree *Tree[TKey, TValue]) Iterator() containers.ReverseIteratorWithKey[TKe
 	return &Iterator[TKey, TValue]{tree: tree, node: nil, position: begin}
 }
 
+// Seek returns a stateful iterator positioned at the first element whose key is greater than or equal to the given key,
+// so that Next() and Prev() continue the in-order traversal from there (see Iterator.Seek).
+// If there is no such element, the iterator is positioned one-past-the-end and second return parameter is false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) Seek(key TKey) (*Iterator[TKey, TValue], bool) {
+	iterator := &Iterator[TKey, TValue]{tree: tree, node: nil, position: begin}
+	return iterator, iterator.Seek(key)
+}
+
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
 // If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
@@ -67,6 +76,23 @@ func (iterator *Iterator[TKey, TValue]) Prev() bool {
 	return true
 }
 
+// Seek moves the iterator to the first element whose key is greater than or equal to the given key
+// and returns true if there was such an element in the container.
+// Descends the tree from the root, so positioning takes O(log n) regardless of the current position.
+// If Seek() returns false, then the iterator is moved past the last element (one-past-the-end).
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+// Modifies the state of the iterator.
+func (iterator *Iterator[TKey, TValue]) Seek(key TKey) bool {
+	node, found := iterator.tree.Ceiling(key)
+	if !found {
+		iterator.End()
+		return false
+	}
+	iterator.node = node
+	iterator.position = between
+	return true
+}
+
 // Value returns the current element's value.
 // Does not modify the state of the iterator.
 func (iterator *Iterator[TKey, TValue]) Value() TValue {
@@ -27,6 +27,7 @@ var _ trees.Tree[int] = (*Heap[int])(nil)
 type Heap[T comparable] struct {
 	list       *arraylist.List[T]
 	Comparator utils.Comparator
+	capacity   int // maximum number of elements kept by Push, unbounded if zero
 }
 
 // NewWith instantiates a new empty heap tree with the custom comparator.
@@ -44,23 +45,53 @@ func NewWithStringComparator[T comparable]() *Heap[T] {
 	return &Heap[T]{list: arraylist.New[T](), Comparator: utils.StringComparator}
 }
 
+// NewMinHeap instantiates a new empty min-heap for any ordered type, i.e. Pop returns the smallest element first.
+func NewMinHeap[T utils.Ordered]() *Heap[T] {
+	return NewWith[T](utils.OrderedComparator[T]())
+}
+
+// NewMaxHeap instantiates a new empty max-heap for any ordered type, i.e. Pop returns the largest element first.
+func NewMaxHeap[T utils.Ordered]() *Heap[T] {
+	return NewWith[T](utils.Reverse(utils.OrderedComparator[T]()))
+}
+
+// NewBounded instantiates a new empty heap with the custom comparator that holds at most capacity elements.
+// Once the heap is full, Push compares each incoming element with the top of the heap: if the incoming element
+// sorts after the top, the top is evicted and the incoming element takes its place, otherwise the incoming
+// element is dropped. The heap thus keeps the capacity "greatest" elements pushed so far according to the
+// comparator, e.g. a heap with the IntComparator (a min-heap) keeps the N largest ints and a heap with
+// a reversed comparator (a max-heap) keeps the N smallest. Panics if capacity is less than one.
+func NewBounded[T comparable](comparator utils.Comparator, capacity int) *Heap[T] {
+	if capacity < 1 {
+		panic("Invalid capacity, should be at least 1")
+	}
+	return &Heap[T]{list: arraylist.New[T](), Comparator: comparator, capacity: capacity}
+}
+
 // Push adds a value onto the heap and bubbles it up accordingly.
+// For a bounded heap (see NewBounded) that is full, the value either replaces the top element or is dropped.
 func (heap *Heap[T]) Push(values ...T) {
-	if len(values) == 1 {
+	if heap.capacity > 0 {
+		for _, value := range values {
+			heap.pushBounded(value)
+		}
+	} else if len(values) == 1 {
 		heap.list.Add(values[0])
 		heap.bubbleUp()
 	} else {
-		// Reference: https://en.wikipedia.org/wiki/Binary_heap#Building_a_heap
 		for _, value := range values {
 			heap.list.Add(value)
 		}
-		size := heap.list.Size()/2 + 1
-		for i := size; i >= 0; i-- {
-			heap.bubbleDownIndex(i)
-		}
+		heap.heapify()
 	}
 }
 
+// SetComparator replaces the comparator of the heap and rebuilds the heap order in O(n).
+func (heap *Heap[T]) SetComparator(comparator utils.Comparator) {
+	heap.Comparator = comparator
+	heap.heapify()
+}
+
 // Pop removes top element on heap and returns it, or nil if heap is empty.
 // Second return parameter is true, unless the heap was empty and there was nothing to pop.
 func (heap *Heap[T]) Pop() (value T, ok bool) {
@@ -75,12 +106,98 @@ func (heap *Heap[T]) Pop() (value T, ok bool) {
 	return
 }
 
+// Replace removes the top element of the heap and pushes value in its place, sifting down only once,
+// which is cheaper than a Pop followed by a Push. The returned element may sort before value.
+// Second return parameter is true, unless the heap was empty, in which case value is just pushed.
+func (heap *Heap[T]) Replace(value T) (old T, ok bool) {
+	old, ok = heap.list.Get(0)
+	if !ok {
+		heap.Push(value)
+		return
+	}
+	heap.list.Set(0, value)
+	heap.bubbleDown()
+	return
+}
+
+// PushPop pushes value onto the heap and then removes and returns the top element, i.e. the one that sorts first
+// among the heap's elements and value. If value sorts before or equal to the top (or the heap is empty),
+// value is returned immediately without modifying the heap, otherwise the top is replaced with a single sift down.
+func (heap *Heap[T]) PushPop(value T) T {
+	if top, ok := heap.list.Get(0); ok && heap.Comparator(top, value) < 0 {
+		heap.list.Set(0, value)
+		heap.bubbleDown()
+		return top
+	}
+	return value
+}
+
 // Peek returns top element on the heap without removing it, or nil if heap is empty.
 // Second return parameter is true, unless the heap was empty and there was nothing to peek.
 func (heap *Heap[T]) Peek() (value T, ok bool) {
 	return heap.list.Get(0)
 }
 
+// PeekN returns up to k top elements of the heap in the order they would be popped, without removing them.
+// If k exceeds the size of the heap, all elements are returned.
+func (heap *Heap[T]) PeekN(k int) []T {
+	if k > heap.list.Size() {
+		k = heap.list.Size()
+	}
+	if k <= 0 {
+		return []T{}
+	}
+	tmpHeap := &Heap[T]{list: arraylist.New[T](heap.list.Values()...), Comparator: heap.Comparator}
+	values := make([]T, k)
+	for i := range values {
+		values[i], _ = tmpHeap.Pop()
+	}
+	return values
+}
+
+// Sorted returns all elements of the heap in the order they would be popped, i.e. sorted by the comparator.
+// The elements are popped from a copy of the heap in O(n log n) time, the heap itself is left untouched.
+func (heap *Heap[T]) Sorted() []T {
+	return heap.PeekN(heap.list.Size())
+}
+
+// Update replaces the element at the given index of the underlying array with value and
+// re-establishes the heap order, moving the element up or down as necessary.
+// Does nothing if the index is out of range.
+func (heap *Heap[T]) Update(index int, value T) {
+	if !heap.withinRange(index) {
+		return
+	}
+	heap.list.Set(index, value)
+	heap.Fix(index)
+}
+
+// Fix re-establishes the heap order after the element at the given index of the underlying array
+// has changed its value (e.g. it was mutated in place).
+// Does nothing if the index is out of range.
+func (heap *Heap[T]) Fix(index int) {
+	if !heap.withinRange(index) {
+		return
+	}
+	heap.bubbleUpIndex(index)
+	heap.bubbleDownIndex(index)
+}
+
+// IndexOf returns the index of the given value within the underlying array, suitable for Update and Fix,
+// and true if the value was found, otherwise -1 and false.
+// Performs a linear scan, so the lookup is O(n).
+func (heap *Heap[T]) IndexOf(value T) (int, bool) {
+	index := heap.list.IndexOf(value)
+	return index, index >= 0
+}
+
+// Contains returns true if the given value is in the heap.
+// Performs a linear scan, so the lookup is O(n).
+func (heap *Heap[T]) Contains(value T) bool {
+	_, found := heap.IndexOf(value)
+	return found
+}
+
 // Empty returns true if heap does not contain any elements.
 func (heap *Heap[T]) Empty() bool {
 	return heap.list.Empty()
@@ -91,12 +208,16 @@ func (heap *Heap[T]) Size() int {
 	return heap.list.Size()
 }
 
-// Clear removes all elements from the heap.
+// Clear removes all elements from the heap but retains the capacity of the underlying array,
+// so that refilling a reused heap does not need to grow it again.
 func (heap *Heap[T]) Clear() {
-	heap.list.Clear()
+	heap.list.ClearKeepCapacity()
 }
 
-// Values returns all elements in the heap.
+// Values returns all elements in the heap without modifying it.
+// Elements are returned in the order of the iterator, i.e. level by level of the heap, each level sorted
+// by the comparator, so the first element is always the one that Pop would return.
+// Positions in this order are not the indices taken by Update and Fix, see ArrayValues for those.
 func (heap *Heap[T]) Values() []T {
 	values := make([]T, heap.list.Size(), heap.list.Size())
 	for it := heap.Iterator(); it.Next(); {
@@ -105,6 +226,13 @@ func (heap *Heap[T]) Values() []T {
 	return values
 }
 
+// ArrayValues returns all elements in the order of the heap's backing array without modifying the heap,
+// so that the element at index i is the one addressed by Update(i, value) and Fix(i), and IndexOf returns i for it.
+// The first element is always the one that Pop would return.
+func (heap *Heap[T]) ArrayValues() []T {
+	return heap.list.Values()
+}
+
 // String returns a string representation of container
 func (heap *Heap[T]) String() string {
 	str := "BinaryHeap\n"
@@ -116,6 +244,28 @@ func (heap *Heap[T]) String() string {
 	return str
 }
 
+// Adds the value to a bounded heap, evicting the top element if the heap is full and the value sorts after it.
+func (heap *Heap[T]) pushBounded(value T) {
+	if heap.list.Size() < heap.capacity {
+		heap.list.Add(value)
+		heap.bubbleUp()
+		return
+	}
+	if top, _ := heap.list.Get(0); heap.Comparator(value, top) > 0 {
+		heap.list.Set(0, value)
+		heap.bubbleDown()
+	}
+}
+
+// Rebuilds the min/max-heap order property of all elements bottom-up.
+// Reference: https://en.wikipedia.org/wiki/Binary_heap#Building_a_heap
+func (heap *Heap[T]) heapify() {
+	size := heap.list.Size()/2 + 1
+	for i := size; i >= 0; i-- {
+		heap.bubbleDownIndex(i)
+	}
+}
+
 // Performs the "bubble down" operation. This is to place the element that is at the root
 // of the heap in its correct place so that the heap maintains the min/max-heap order property.
 func (heap *Heap[T]) bubbleDown() {
@@ -149,7 +299,12 @@ func (heap *Heap[T]) bubbleDownIndex(index int) {
 // element (i.e. last element in the list) in its correct place so that
 // the heap maintains the min/max-heap order property.
 func (heap *Heap[T]) bubbleUp() {
-	index := heap.list.Size() - 1
+	heap.bubbleUpIndex(heap.list.Size() - 1)
+}
+
+// Performs the "bubble up" operation. This is to place the element that is at the index
+// of the heap in its correct place so that the heap maintains the min/max-heap order property.
+func (heap *Heap[T]) bubbleUpIndex(index int) {
 	for parentIndex := (index - 1) >> 1; index > 0; parentIndex = (index - 1) >> 1 {
 		indexValue, _ := heap.list.Get(index)
 		parentValue, _ := heap.list.Get(parentIndex)
@@ -6,9 +6,12 @@ package binaryheap
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"strings"
 	"testing"
+
+	"github.com/a234567894/gods/utils"
 )
 
 func TestBinaryHeapPush(t *testing.T) {
@@ -81,6 +84,23 @@ func TestBinaryHeapPop(t *testing.T) {
 	}
 }
 
+func TestBinaryHeapMinMaxHeap(t *testing.T) {
+	minHeap := NewMinHeap[float64]()
+	maxHeap := NewMaxHeap[string]()
+	minHeap.Push(2.5, -1, 3)
+	maxHeap.Push("b", "c", "a")
+	for _, expectedValue := range []float64{-1, 2.5, 3} {
+		if actualValue, ok := minHeap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	for _, expectedValue := range []string{"c", "b", "a"} {
+		if actualValue, ok := maxHeap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
 func TestBinaryHeapRandom(t *testing.T) {
 	heap := NewWithIntComparator[int]()
 
@@ -100,6 +120,347 @@ func TestBinaryHeapRandom(t *testing.T) {
 	}
 }
 
+func TestBinaryHeapSorted(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	if actualValue := heap.Sorted(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	heap.Push(5, 3, 8, 1, 3)
+	if actualValue, expectedValue := fmt.Sprint(heap.Sorted()), "[1 3 3 5 8]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := heap.Size(), 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, _ := heap.Peek(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestBinaryHeapReplace(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	if actualValue, ok := heap.Replace(4); actualValue != 0 || ok {
+		t.Errorf("Got %v %v expected %v %v", actualValue, ok, 0, false)
+	}
+	heap.Push(1, 7, 3)
+	if actualValue, ok := heap.Replace(5); actualValue != 1 || !ok {
+		t.Errorf("Got %v %v expected %v %v", actualValue, ok, 1, true)
+	}
+	if actualValue, ok := heap.Replace(0); actualValue != 3 || !ok {
+		t.Errorf("Got %v %v expected %v %v", actualValue, ok, 3, true)
+	}
+	if actualValue, expectedValue := fmt.Sprint(heap.Sorted()), "[0 4 5 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBinaryHeapPushPop(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	if actualValue := heap.PushPop(4); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue := heap.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	heap.Push(3, 6, 9)
+	if actualValue := heap.PushPop(2); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := heap.PushPop(3); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue := heap.PushPop(8); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, expectedValue := fmt.Sprint(heap.Sorted()), "[6 8 9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBinaryHeapClear(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	heap.Push(3, 1, 2)
+	heap.Clear()
+	if actualValue, expectedValue := fmt.Sprint(heap.Size(), heap.Empty(), heap.Values()), "0 true []"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if allocs := testing.AllocsPerRun(10, func() {
+		heap.Push(5)
+		heap.Push(4)
+		heap.Push(6)
+		heap.Clear()
+	}); allocs != 0 {
+		t.Errorf("Got %v expected %v", allocs, 0)
+	}
+	heap.Push(2, 1)
+	if actualValue, _ := heap.Pop(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestBinaryHeapPeekN(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	if actualValue := heap.PeekN(3); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+
+	heap.Push(5, 3, 9, 1, 7)
+	if actualValue, expectedValue := fmt.Sprintf("%v", heap.PeekN(3)), "[1 3 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", heap.PeekN(10)), "[1 3 5 7 9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := heap.PeekN(0); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+
+	// original heap is untouched
+	if actualValue := heap.Size(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestBinaryHeapUpdate(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	for i := 1; i <= 15; i++ {
+		heap.Push(i * 10)
+	}
+
+	// decrease the key of a leaf so that it bubbles up to the root
+	index := heap.list.IndexOf(150)
+	if actualValue, expectedValue := index, 14; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	heap.Update(index, 5)
+	if actualValue, ok := heap.Peek(); actualValue != 5 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+
+	// decrease the key of a leaf so that it stops in the middle of its path
+	heap.Update(heap.list.IndexOf(140), 25)
+	if actualValue, expectedValue := heap.list.IndexOf(25), 6; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// increase the key of the root so that it sinks down
+	heap.Update(0, 1000)
+
+	// out of range is ignored
+	heap.Update(-1, 0)
+	heap.Update(heap.Size(), 0)
+
+	expected := []int{10, 20, 25, 30, 40, 50, 60, 70, 80, 90, 100, 110, 120, 130, 1000}
+	for _, expectedValue := range expected {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestBinaryHeapArrayValues(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	if actualValue := heap.ArrayValues(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	heap.Push(50, 20, 40, 10, 30, 60)
+	values := heap.ArrayValues()
+	if actualValue, expectedValue := values[0], 10; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i, value := range values {
+		if actualValue, found := heap.IndexOf(value); actualValue != i || !found {
+			t.Errorf("Got %v expected %v", actualValue, i)
+		}
+	}
+
+	// the index addresses the element at the same position of the backing array (increments keep the order)
+	for i, value := range values {
+		heap.Update(i, value+1)
+		if actualValue, expectedValue := heap.Contains(value+1) && !heap.Contains(value), true; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(heap.Sorted()), "[11 21 31 41 51 61]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	values[0] = 0
+	if actualValue, _ := heap.Peek(); actualValue != 11 {
+		t.Errorf("Got %v expected %v", actualValue, 11)
+	}
+}
+
+func TestBinaryHeapFix(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	heap.Push(1, 2, 3, 4, 5, 6, 7)
+
+	heap.list.Set(heap.list.IndexOf(7), 0)
+	heap.Fix(heap.list.IndexOf(0))
+	heap.list.Set(0, 8)
+	heap.Fix(0)
+
+	expected := []int{1, 2, 3, 4, 5, 6, 8}
+	for _, expectedValue := range expected {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestBinaryHeapSetComparator(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	heap.Push(4, 8, 1, 6, 3)
+
+	heap.SetComparator(func(a, b interface{}) int { return -utils.IntComparator(a, b) })
+	heap.Push(5)
+	if actualValue, expectedValue := fmt.Sprintf("%v", heap.PeekN(6)), "[8 6 5 4 3 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	heap.SetComparator(utils.IntComparator)
+	heap.Push(2)
+	expected := []int{1, 2, 3, 4, 5, 6, 8}
+	for _, expectedValue := range expected {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestBinaryHeapCloneWithComparator(t *testing.T) {
+	heap := NewWith[int](func(a, b interface{}) int { return -utils.IntComparator(a, b) })
+	heap.Push(3, 9, 1, 7, 5)
+
+	clone := NewWith[int](heap.Comparator)
+	clone.Push(heap.Values()...)
+	for !heap.Empty() {
+		expectedValue, _ := heap.Pop()
+		if actualValue, ok := clone.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue := clone.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestBinaryHeapValuesDoesNotMutate(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	heap.Push(5, 3, 8, 1, 9, 2)
+	size := heap.Size()
+
+	values := heap.Values()
+	if actualValue, expectedValue := fmt.Sprint(values), "[1 2 3 5 8 9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it := heap.Iterator(); it.Next(); {
+		if actualValue, expectedValue := it.Value(), values[it.Index()]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := heap.Size(), size; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// modifying the returned slice does not affect the heap
+	values[0] = 100
+	popped := ""
+	for !heap.Empty() {
+		value, _ := heap.Pop()
+		popped += fmt.Sprint(value)
+	}
+	if actualValue, expectedValue := popped, "123589"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBinaryHeapBounded(t *testing.T) {
+	heap := NewBounded[int](utils.IntComparator, 3)
+	heap.Push(5)
+	heap.Push(1, 9, 3)
+	heap.Push(7)
+	heap.Push(2)
+	if actualValue, expectedValue := heap.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	popped := ""
+	for !heap.Empty() {
+		value, _ := heap.Pop()
+		popped += fmt.Sprint(value)
+	}
+	if actualValue, expectedValue := popped, "579"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// a max-heap keeps the smallest elements
+	heap = NewBounded[int](utils.Reverse(utils.IntComparator), 2)
+	for i := 10; i > 0; i-- {
+		heap.Push(i)
+	}
+	if actualValue, expectedValue := fmt.Sprint(heap.Values()), "[2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on invalid capacity")
+		}
+	}()
+	NewBounded[int](utils.IntComparator, 0)
+}
+
+func TestBinaryHeapPriorityQueue(t *testing.T) {
+	queue := NewPriorityQueue[int, string](utils.IntComparator)
+	if actualPriority, actualValue, ok := queue.Dequeue(); actualPriority != 0 || actualValue != "" || ok {
+		t.Errorf("Got %v %v %v expected %v %v %v", actualPriority, actualValue, ok, 0, "", false)
+	}
+	queue.Enqueue(3, "c")
+	queue.Enqueue(1, "a")
+	queue.Enqueue(2, "b")
+	queue.Enqueue(5, "e")
+	if actualValue, expectedValue := queue.Size(), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualPriority, actualValue, ok := queue.Peek(); actualPriority != 1 || actualValue != "a" || !ok {
+		t.Errorf("Got %v %v %v expected %v %v %v", actualPriority, actualValue, ok, 1, "a", true)
+	}
+	if actualValue, expectedValue := queue.Values()[0], "a"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := queue.String(), "PriorityQueue\n1:a, 2:b, 3:c, 5:e"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	dequeued := []string{}
+	for !queue.Empty() {
+		priority, value, _ := queue.Dequeue()
+		dequeued = append(dequeued, fmt.Sprintf("%d:%s", priority, value))
+	}
+	if actualValue, expectedValue := strings.Join(dequeued, " "), "1:a 2:b 3:c 5:e"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// equal values with different priorities are kept apart
+	maxQueue := NewPriorityQueue[float64, string](utils.Reverse(utils.Float64Comparator))
+	maxQueue.Enqueue(0.5, "x")
+	maxQueue.Enqueue(2.5, "x")
+	maxQueue.Enqueue(1.5, "y")
+	if actualPriority, actualValue, ok := maxQueue.Dequeue(); actualPriority != 2.5 || actualValue != "x" || !ok {
+		t.Errorf("Got %v %v %v expected %v %v %v", actualPriority, actualValue, ok, 2.5, "x", true)
+	}
+	maxQueue.Clear()
+	if actualValue := maxQueue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestBinaryHeapIteratorOnEmpty(t *testing.T) {
 	heap := NewWithIntComparator[int]()
 	it := heap.Iterator()
@@ -261,6 +622,28 @@ func TestBinaryHeapIteratorLast(t *testing.T) {
 	}
 }
 
+func TestBinaryHeapIndexOf(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	if index, found := heap.IndexOf(1); index != -1 || found {
+		t.Errorf("Got %v %v expected %v %v", index, found, -1, false)
+	}
+	heap.Push(5, 3, 8, 1)
+	index, found := heap.IndexOf(8)
+	if !found {
+		t.Errorf("Got %v expected %v", found, true)
+	}
+	heap.Update(index, 0)
+	if actualValue, _ := heap.Peek(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue, expectedValue := heap.Contains(8), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := heap.Contains(3), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestBinaryHeapIteratorNextTo(t *testing.T) {
 	// Sample seek function, i.e. string starting with "b"
 	seek := func(index int, value string) bool {
@@ -409,6 +792,48 @@ func TestBinaryHeapSerialization(t *testing.T) {
 	}
 }
 
+func TestBinaryHeapFromJSONHeapifies(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	heap.Push(100)
+	if err := heap.FromJSON([]byte(`[9,8,7,6,5,4,3,2,1]`)); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	popped := ""
+	for !heap.Empty() {
+		value, _ := heap.Pop()
+		popped += fmt.Sprint(value)
+	}
+	if actualValue, expectedValue := popped, "123456789"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if err := heap.FromJSON([]byte(`[3,1,2`)); err == nil {
+		t.Errorf("Expected error on malformed input")
+	}
+}
+
+func TestBinaryHeapFromJSONBounded(t *testing.T) {
+	heap := NewBounded[int](utils.IntComparator, 2)
+	if err := heap.FromJSON([]byte(`[4,3,2,1,7]`)); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := heap.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	heap.Push(5)
+	if actualValue, expectedValue := heap.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	popped := ""
+	for !heap.Empty() {
+		value, _ := heap.Pop()
+		popped += fmt.Sprint(value)
+	}
+	if actualValue, expectedValue := popped, "57"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestBTreeString(t *testing.T) {
 	c := NewWithIntComparator[int]()
 	c.Push(1)
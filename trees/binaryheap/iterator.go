@@ -18,6 +18,9 @@ type Iterator[T comparable] struct {
 }
 
 // Iterator returns a stateful iterator whose values can be fetched by an index.
+// Iterating does not modify the heap. Values are yielded level by level of the heap, each level sorted by the
+// comparator, which is neither the order of the backing array nor a fully sorted order, so the iterator's
+// Index is not the index taken by Update and Fix (see ArrayValues).
 func (heap *Heap[T]) Iterator() Iterator[T] {
 	return Iterator[T]{heap: heap, index: -1}
 }
@@ -0,0 +1,93 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package binaryheap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a234567894/gods/containers"
+	"github.com/a234567894/gods/utils"
+)
+
+// Assert Container implementation
+var _ containers.Container[string] = (*PriorityQueue[int, string])(nil)
+
+// PriorityQueue holds values ordered by a separate priority in a binary heap.
+//
+// The comparator orders the priorities, so the value whose priority sorts first (e.g. the smallest one with the
+// IntComparator) is dequeued first. Values with equal priorities are dequeued in no particular order.
+type PriorityQueue[P, V comparable] struct {
+	heap *Heap[prioritized[P, V]]
+}
+
+// prioritized is a value paired with its priority as stored in the heap
+type prioritized[P, V comparable] struct {
+	priority P
+	value    V
+}
+
+// NewPriorityQueue instantiates a new empty priority queue with the custom comparator of priorities.
+func NewPriorityQueue[P, V comparable](comparator utils.Comparator) *PriorityQueue[P, V] {
+	return &PriorityQueue[P, V]{heap: NewWith[prioritized[P, V]](func(a, b interface{}) int {
+		return comparator(a.(prioritized[P, V]).priority, b.(prioritized[P, V]).priority)
+	})}
+}
+
+// Enqueue adds a value with the given priority to the queue.
+func (queue *PriorityQueue[P, V]) Enqueue(priority P, value V) {
+	queue.heap.Push(prioritized[P, V]{priority: priority, value: value})
+}
+
+// Dequeue removes the value whose priority sorts first and returns it along with its priority.
+// Third return parameter is true, unless the queue was empty and there was nothing to dequeue.
+func (queue *PriorityQueue[P, V]) Dequeue() (priority P, value V, ok bool) {
+	element, ok := queue.heap.Pop()
+	return element.priority, element.value, ok
+}
+
+// Peek returns the value whose priority sorts first along with its priority, without removing it.
+// Third return parameter is true, unless the queue was empty and there was nothing to peek.
+func (queue *PriorityQueue[P, V]) Peek() (priority P, value V, ok bool) {
+	element, ok := queue.heap.Peek()
+	return element.priority, element.value, ok
+}
+
+// Empty returns true if queue does not contain any elements.
+func (queue *PriorityQueue[P, V]) Empty() bool {
+	return queue.heap.Empty()
+}
+
+// Size returns number of elements within the queue.
+func (queue *PriorityQueue[P, V]) Size() int {
+	return queue.heap.Size()
+}
+
+// Clear removes all elements from the queue.
+func (queue *PriorityQueue[P, V]) Clear() {
+	queue.heap.Clear()
+}
+
+// Values returns all values in the queue without their priorities, in the order of the heap's Values,
+// so the first value is always the one that Dequeue would return.
+func (queue *PriorityQueue[P, V]) Values() []V {
+	elements := queue.heap.Values()
+	values := make([]V, len(elements))
+	for i, element := range elements {
+		values[i] = element.value
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (queue *PriorityQueue[P, V]) String() string {
+	str := "PriorityQueue\n"
+	values := []string{}
+	for _, element := range queue.heap.Values() {
+		values = append(values, fmt.Sprintf("%v:%v", element.priority, element.value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
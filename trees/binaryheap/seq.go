@@ -0,0 +1,23 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package binaryheap
+
+import "iter"
+
+// Iter returns a sequence of the heap's values in the order of the iterator for use with range-over-func,
+// e.g. for value := range heap.Iter() { ... }. Iteration does not modify the heap and stops as soon as
+// the loop body breaks.
+func (heap *Heap[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		iterator := heap.Iterator()
+		for iterator.Next() {
+			if !yield(iterator.Value()) {
+				return
+			}
+		}
+	}
+}
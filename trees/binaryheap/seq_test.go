@@ -0,0 +1,37 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package binaryheap
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBinaryHeapIter(t *testing.T) {
+	heap := NewWithIntComparator[int]()
+	for range heap.Iter() {
+		t.Errorf("Shouldn't iterate on empty heap")
+	}
+	heap.Push(3, 1, 2)
+
+	values := []int{}
+	for value := range heap.Iter() {
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(values), fmt.Sprint(heap.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := heap.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for value := range heap.Iter() {
+		if value != 1 {
+			t.Errorf("Got %v expected %v", value, 1)
+		}
+		break
+	}
+}
@@ -18,8 +18,23 @@ func (heap *Heap[int]) ToJSON() ([]byte, error) {
 }
 
 // FromJSON populates the heap from the input JSON representation.
+// The elements may be in any order, the heap is rebuilt with its own comparator after loading them.
+// A bounded heap (see NewBounded) keeps only as many of the elements as its capacity, as if they were pushed.
 func (heap *Heap[int]) FromJSON(data []byte) error {
-	return heap.list.FromJSON(data)
+	err := heap.list.FromJSON(data)
+	if err != nil {
+		return err
+	}
+	if heap.capacity > 0 && heap.list.Size() > heap.capacity {
+		values := heap.list.Values()
+		heap.list.Clear()
+		for _, value := range values {
+			heap.pushBounded(value)
+		}
+		return nil
+	}
+	heap.heapify()
+	return nil
 }
 
 // UnmarshalJSON @implements json.Unmarshaler
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"unsafe"
 
 	"github.com/a234567894/gods/trees"
 	"github.com/a234567894/gods/utils"
@@ -30,17 +31,41 @@ var _ trees.Tree[int] = (*Tree[int, int])(nil)
 
 // Tree holds elements of the B-tree
 type Tree[TKey, TValue comparable] struct {
-	Root       *Node[TKey, TValue] // Root node
-	Comparator utils.Comparator    // Key comparator
-	size       int                 // Total number of keys in the tree
-	m          int                 // order (maximum number of children)
+	Root           *Node[TKey, TValue]               // Root node
+	Comparator     utils.Comparator                  // Key comparator
+	KeyFormatter   func(key TKey) string             // Formats keys in String and StringWithValues, %v if nil
+	ValueFormatter func(value TValue) string         // Formats values in StringWithValues, %v if nil
+	size           int                               // Total number of keys in the tree
+	m              int                               // order (maximum number of children)
+	splitBias      SplitBias                         // which node gets the extra key when splitting
+	multimap       bool                              // whether equal keys keep all their values
+	duplicates     map[*Entry[TKey, TValue]][]TValue // values put after an entry's value under the same key (multimap mode only)
+}
+
+// SplitBias determines which of the two resulting nodes receives the extra key when a node with an even number
+// of keys is split, i.e. when the order of the tree is even.
+type SplitBias int
+
+const (
+	// RightHeavy favors the right node when splitting (default).
+	RightHeavy SplitBias = iota
+	// LeftHeavy favors the left node when splitting, which results in better node occupancy
+	// for monotonically increasing insertions.
+	LeftHeavy
+)
+
+// Options holds optional settings of the B-tree.
+type Options struct {
+	SplitBias       SplitBias // which node gets the extra key when splitting (RightHeavy by default)
+	AllowDuplicates bool      // whether putting an existing key adds the value instead of replacing it (multimap mode)
 }
 
 // Node is a single element within the tree
 type Node[TKey, TValue comparable] struct {
-	Parent   *Node[TKey, TValue]
+	Parent   *Node[TKey, TValue]    // Parent node, unreliable if the node is shared (see PutPersistent)
 	Entries  []*Entry[TKey, TValue] // Contained keys in node
 	Children []*Node[TKey, TValue]  // Children nodes
+	shared   bool                   // whether the node may be referenced by several trees and must be copied before modification
 }
 
 // Entry represents the key-value pair contained within nodes
@@ -57,6 +82,17 @@ func NewWith[TKey, TValue comparable](order int, comparator utils.Comparator) *T
 	return &Tree[TKey, TValue]{m: order, Comparator: comparator}
 }
 
+// NewWithOptions instantiates a B-tree with the order (maximum number of children), a custom key comparator and the given options.
+func NewWithOptions[TKey, TValue comparable](order int, comparator utils.Comparator, options Options) *Tree[TKey, TValue] {
+	tree := NewWith[TKey, TValue](order, comparator)
+	tree.splitBias = options.SplitBias
+	tree.multimap = options.AllowDuplicates
+	if tree.multimap {
+		tree.duplicates = make(map[*Entry[TKey, TValue]][]TValue)
+	}
+	return tree
+}
+
 // NewWithIntComparator instantiates a B-tree with the order (maximum number of children) and the IntComparator, i.e. keys are of type int.
 func NewWithIntComparator[TKey, TValue comparable](order int) *Tree[TKey, TValue] {
 	return NewWith[TKey, TValue](order, utils.IntComparator)
@@ -68,19 +104,84 @@ func NewWithStringComparator[TKey, TValue comparable](order int) *Tree[TKey, TVa
 }
 
 // Put inserts key-value pair node into the tree.
-// If key already exists, then its value is updated with the new value.
+// If key already exists, then its value is updated with the new value,
+// unless the tree allows duplicates, in which case the value is added after the key's existing values.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (tree *Tree[TKey, TValue]) Put(key TKey, value TValue) {
-	entry := &Entry[TKey, TValue]{Key: key, Value: value}
+	tree.put(&Entry[TKey, TValue]{Key: key, Value: value})
+}
 
-	if tree.Root == nil {
-		tree.Root = &Node[TKey, TValue]{Entries: []*Entry[TKey, TValue]{entry}, Children: []*Node[TKey, TValue]{}}
-		tree.size++
-		return
+// PutWithPrevious inserts key-value pair node into the tree like Put and returns the value it replaced.
+// Second return parameter is true if an existing value was replaced, otherwise false,
+// which is always the case if the tree allows duplicates as values of an existing key are kept.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) PutWithPrevious(key TKey, value TValue) (old TValue, replaced bool) {
+	if previous := tree.put(&Entry[TKey, TValue]{Key: key, Value: value}); previous != nil {
+		return previous.Value, true
+	}
+	return old, false
+}
+
+// PutPersistent returns a new tree holding the entries of this tree plus the given key-value pair as if by Put,
+// leaving this tree unchanged, so that it remains a consistent snapshot of the previous version.
+// Only the nodes on the path from the root to the key are copied (along with the nodes their splits create),
+// every other subtree is shared between both trees, so a new version costs O(log n) node allocations.
+// Later modifications of either tree copy the shared nodes they touch first, and entries are never modified
+// in place but replaced, so neither tree observes changes made to the other.
+// If the tree allows duplicates, the repeated values of all keys are copied as well.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) PutPersistent(key TKey, value TValue) *Tree[TKey, TValue] {
+	version := &Tree[TKey, TValue]{
+		Root:           tree.Root,
+		Comparator:     tree.Comparator,
+		KeyFormatter:   tree.KeyFormatter,
+		ValueFormatter: tree.ValueFormatter,
+		size:           tree.size,
+		m:              tree.m,
+		splitBias:      tree.splitBias,
+		multimap:       tree.multimap,
+	}
+	if tree.multimap {
+		version.duplicates = make(map[*Entry[TKey, TValue]][]TValue, len(tree.duplicates))
+		for entry, values := range tree.duplicates {
+			version.duplicates[entry] = append([]TValue(nil), values...)
+		}
+	}
+	if tree.Root != nil {
+		tree.Root.shared = true
 	}
+	version.Put(key, value)
+	return version
+}
 
-	if tree.insert(tree.Root, entry) {
-		tree.size++
+// AbsorbSorted merges all entries of the other tree into this tree by iterating the other tree in order.
+// The trees may be of different order. If a key exists in both trees, the value stored is the one returned
+// by the given resolve function, which receives the key, this tree's value and the other tree's value.
+// If the tree allows duplicates, no conflicts occur and the other tree's values are added as if by Put.
+// If all keys of the other tree sort after (or before) all keys of this tree, its entries are added directly
+// to the right-most (left-most) leaf without searching for them, which takes O(m + log n) for m entries.
+// Otherwise every entry is put with a single descent of the tree, so absorbing m entries takes O(m log(n+m)).
+// The other tree is not modified.
+func (tree *Tree[TKey, TValue]) AbsorbSorted(other *Tree[TKey, TValue], resolve func(key TKey, a, b TValue) TValue) {
+	if other.Empty() {
+		return
+	}
+	if tree.Empty() || tree.Comparator(tree.RightKey(), other.LeftKey()) < 0 {
+		tree.absorbDisjoint(other, false, resolve)
+		return
+	}
+	if tree.Comparator(other.RightKey(), tree.LeftKey()) < 0 {
+		tree.absorbDisjoint(other, true, resolve)
+		return
+	}
+	it := other.Iterator()
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		entry := &Entry[TKey, TValue]{Key: key, Value: value}
+		if replaced := tree.put(entry); replaced != nil {
+			// the entry has just been stored and is not shared with any other tree yet
+			entry.Value = resolve(key, replaced.Value, value)
+		}
 	}
 }
 
@@ -95,6 +196,37 @@ func (tree *Tree[TKey, TValue]) Get(key TKey) (value TValue, found bool) {
 	return *new(TValue), false
 }
 
+// GetAll returns all values of the given key in the order they were put, or an empty slice if key is not found in tree.
+// Unless the tree allows duplicates, at most one value is returned.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) GetAll(key TKey) []TValue {
+	node, index, found := tree.searchRecursively(tree.Root, key)
+	if !found {
+		return []TValue{}
+	}
+	entry := node.Entries[index]
+	return append([]TValue{entry.Value}, tree.duplicates[entry]...)
+}
+
+// GetEntry searches the entry in the tree by key and returns a copy of it or nil if key is not found in tree.
+// The entry is copied, as stored entries may be shared with other trees (see PutPersistent).
+// Second return parameter is true if key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) GetEntry(key TKey) (*Entry[TKey, TValue], bool) {
+	node, index, found := tree.searchRecursively(tree.Root, key)
+	if found {
+		return copyEntry(node.Entries[index]), true
+	}
+	return nil, false
+}
+
+// Contains returns true if the key is in the tree, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) Contains(key TKey) bool {
+	_, _, found := tree.searchRecursively(tree.Root, key)
+	return found
+}
+
 // GetNode searches the node in the tree by key and returns its node or nil if key is not found in tree.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (tree *Tree[TKey, TValue]) GetNode(key TKey) *Node[TKey, TValue] {
@@ -102,14 +234,65 @@ func (tree *Tree[TKey, TValue]) GetNode(key TKey) *Node[TKey, TValue] {
 	return node
 }
 
+// Next returns a copy of the entry with the smallest key strictly greater than the given key, descending the tree in O(log n).
+// The given key does not need to be present in the tree.
+// Second return parameter is false if there is no such entry, e.g. the key is the maximum key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) Next(key TKey) (*Entry[TKey, TValue], bool) {
+	if node, index, found := tree.next(key); found {
+		return copyEntry(node.Entries[index]), true
+	}
+	return nil, false
+}
+
+// Prev returns a copy of the entry with the largest key strictly smaller than the given key, descending the tree in O(log n).
+// The given key does not need to be present in the tree.
+// Second return parameter is false if there is no such entry, e.g. the key is the minimum key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) Prev(key TKey) (*Entry[TKey, TValue], bool) {
+	if node, index, found := tree.prev(key); found {
+		return copyEntry(node.Entries[index]), true
+	}
+	return nil, false
+}
+
+// CountRange returns the number of keys k with lo <= k <= hi (both bounds inclusive), or 0 if lo > hi.
+// Only the subtrees overlapping the range are visited, so it takes O(k + log n) time for k counted keys.
+func (tree *Tree[TKey, TValue]) CountRange(lo, hi TKey) int {
+	if tree.Comparator(lo, hi) > 0 {
+		return 0
+	}
+	return tree.countRange(tree.Root, lo, hi)
+}
+
 // Remove remove the node from the tree by key.
+// If the tree allows duplicates, all values of the key are removed, see RemoveKey to remove a single value.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (tree *Tree[TKey, TValue]) Remove(key TKey) {
-	node, index, found := tree.searchRecursively(tree.Root, key)
-	if found {
-		tree.delete(node, index)
+	tree.RemoveKey(key, true)
+}
+
+// RemoveKey removes either all values of the given key, or only its most recently put value if all is false
+// and the key holds several values (only possible if the tree allows duplicates).
+// Returns the number of removed values.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) RemoveKey(key TKey, all bool) int {
+	node, index, found := tree.searchClaiming(key)
+	if !found {
+		return 0
+	}
+	entry := node.Entries[index]
+	duplicates := tree.duplicates[entry]
+	if !all && len(duplicates) > 0 {
+		tree.duplicates[entry] = duplicates[:len(duplicates)-1]
 		tree.size--
+		return 1
 	}
+	removed := 1 + len(duplicates)
+	delete(tree.duplicates, entry)
+	tree.delete(node, index)
+	tree.size -= removed
+	return removed
 }
 
 // Empty returns true if tree does not contain any nodes
@@ -155,17 +338,84 @@ func (tree *Tree[TKey, TValue]) Values() []TValue {
 	return values
 }
 
+// KeysInto returns all keys in-order, reusing the given buffer for the result.
+// The buffer is overwritten from its start and only reallocated if its capacity is smaller than the size of the tree,
+// so repeated snapshots into the same buffer do not allocate.
+func (tree *Tree[TKey, TValue]) KeysInto(buf []TKey) []TKey {
+	if cap(buf) < tree.size {
+		buf = make([]TKey, 0, tree.size)
+	}
+	buf = buf[:0]
+	for it := tree.Iterator(); it.Next(); {
+		buf = append(buf, it.Key())
+	}
+	return buf
+}
+
+// ValuesInto returns all values in-order based on the key, reusing the given buffer for the result.
+// The buffer is overwritten from its start and only reallocated if its capacity is smaller than the size of the tree,
+// so repeated snapshots into the same buffer do not allocate.
+func (tree *Tree[TKey, TValue]) ValuesInto(buf []TValue) []TValue {
+	if cap(buf) < tree.size {
+		buf = make([]TValue, 0, tree.size)
+	}
+	buf = buf[:0]
+	for it := tree.Iterator(); it.Next(); {
+		buf = append(buf, it.Value())
+	}
+	return buf
+}
+
+// Validate checks the B-tree invariants by a full traversal, i.e. that keys are strictly ordered with respect to
+// the comparator, every non-root node holds between ceil(m/2)-1 and m-1 entries, internal nodes have one child
+// more than entries, parent links of unshared nodes are consistent, all leaves are at the same depth and the size is accurate.
+// Returns an error describing the first violation, otherwise nil.
+func (tree *Tree[TKey, TValue]) Validate() error {
+	if tree.Root == nil {
+		if tree.size != 0 {
+			return fmt.Errorf("empty tree has size %d", tree.size)
+		}
+		return nil
+	}
+	if tree.Root.Parent != nil {
+		return fmt.Errorf("root %v has a parent", tree.Root.Entries)
+	}
+	leafDepth := -1
+	size, err := tree.validate(tree.Root, nil, nil, 0, &leafDepth)
+	if err != nil {
+		return err
+	}
+	if size != tree.size {
+		return fmt.Errorf("tree has %d elements, size is %d", size, tree.size)
+	}
+	return nil
+}
+
 // Clear removes all nodes from the tree.
 func (tree *Tree[TKey, TValue]) Clear() {
 	tree.Root = nil
 	tree.size = 0
+	if tree.multimap {
+		tree.duplicates = make(map[*Entry[TKey, TValue]][]TValue)
+	}
 }
 
-// Height returns the height of the tree.
+// Height returns the height of the tree, i.e. the number of levels, which is 0 for an empty tree.
 func (tree *Tree[TKey, TValue]) Height() int {
 	return tree.Root.height()
 }
 
+// ApproxMemoryBytes returns an estimate of the heap memory held by the tree's nodes and entries in bytes.
+// Accounts for node and entry structs as well as the capacity of the entries' and children's slices,
+// but not for memory referenced by keys or values (e.g. string contents) nor allocator overhead,
+// so the result is an estimate and not an exact measure. Returns zero for an empty tree.
+func (tree *Tree[TKey, TValue]) ApproxMemoryBytes() int {
+	if tree.Empty() {
+		return 0
+	}
+	return tree.approxMemoryBytes(tree.Root)
+}
+
 // Left returns the left-most (min) node or nil if tree is empty.
 func (tree *Tree[TKey, TValue]) Left() *Node[TKey, TValue] {
 	return tree.left(tree.Root)
@@ -208,12 +458,42 @@ func (tree *Tree[TKey, TValue]) RightValue() TValue {
 	return *new(TValue)
 }
 
+// EachInOrder calls the given function once for each element in ascending key order,
+// passing that element's key and value. Traversal stops as soon as the function returns false.
+// Unlike the iterator, it walks the tree recursively and never searches nodes to find its way back up.
+func (tree *Tree[TKey, TValue]) EachInOrder(f func(key TKey, value TValue) bool) {
+	if tree.Empty() {
+		return
+	}
+	tree.eachInOrder(tree.Root, f)
+}
+
+// ReverseEach calls the given function once for each element in descending key order,
+// passing that element's key and value. Traversal stops as soon as the function returns false.
+func (tree *Tree[TKey, TValue]) ReverseEach(f func(key TKey, value TValue) bool) {
+	if tree.Empty() {
+		return
+	}
+	tree.reverseEach(tree.Root, f)
+}
+
 // String returns a string representation of container (for debugging purposes)
 func (tree *Tree[TKey, TValue]) String() string {
 	var buffer bytes.Buffer
 	buffer.WriteString("BTree\n")
 	if !tree.Empty() {
-		tree.output(&buffer, tree.Root, 0, true)
+		tree.output(&buffer, tree.Root, 0, true, false)
+	}
+	return buffer.String()
+}
+
+// StringWithValues returns a string representation of container in the same shape as String,
+// printing every entry as key:value. If the key holds several values (multimap mode), they are printed as a list.
+func (tree *Tree[TKey, TValue]) StringWithValues() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("BTree\n")
+	if !tree.Empty() {
+		tree.output(&buffer, tree.Root, 0, true, true)
 	}
 	return buffer.String()
 }
@@ -222,18 +502,153 @@ func (entry *Entry[TKey, TValue]) String() string {
 	return fmt.Sprintf("%v", entry.Key)
 }
 
-func (tree *Tree[TKey, TValue]) output(buffer *bytes.Buffer, node *Node[TKey, TValue], level int, isTail bool) {
+func (tree *Tree[TKey, TValue]) output(buffer *bytes.Buffer, node *Node[TKey, TValue], level int, isTail bool, withValues bool) {
 	for e := 0; e < len(node.Entries)+1; e++ {
 		if e < len(node.Children) {
-			tree.output(buffer, node.Children[e], level+1, true)
+			tree.output(buffer, node.Children[e], level+1, true, withValues)
 		}
 		if e < len(node.Entries) {
+			entry := node.Entries[e]
 			buffer.WriteString(strings.Repeat("    ", level))
-			buffer.WriteString(fmt.Sprintf("%v", node.Entries[e].Key) + "\n")
+			buffer.WriteString(tree.formatKey(entry.Key))
+			if withValues {
+				buffer.WriteString(":")
+				if duplicates := tree.duplicates[entry]; len(duplicates) > 0 {
+					values := make([]string, 0, len(duplicates)+1)
+					for _, value := range append([]TValue{entry.Value}, duplicates...) {
+						values = append(values, tree.formatValue(value))
+					}
+					buffer.WriteString("[" + strings.Join(values, " ") + "]")
+				} else {
+					buffer.WriteString(tree.formatValue(entry.Value))
+				}
+			}
+			buffer.WriteString("\n")
+		}
+	}
+}
+
+func (tree *Tree[TKey, TValue]) formatKey(key TKey) string {
+	if tree.KeyFormatter != nil {
+		return tree.KeyFormatter(key)
+	}
+	return fmt.Sprintf("%v", key)
+}
+
+func (tree *Tree[TKey, TValue]) formatValue(value TValue) string {
+	if tree.ValueFormatter != nil {
+		return tree.ValueFormatter(value)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// eachInOrder visits the subtree rooted at node from left to right and returns false if traversal was stopped
+func (tree *Tree[TKey, TValue]) eachInOrder(node *Node[TKey, TValue], f func(key TKey, value TValue) bool) bool {
+	for e, entry := range node.Entries {
+		if e < len(node.Children) && !tree.eachInOrder(node.Children[e], f) {
+			return false
+		}
+		if !f(entry.Key, entry.Value) {
+			return false
+		}
+		for _, value := range tree.duplicates[entry] {
+			if !f(entry.Key, value) {
+				return false
+			}
+		}
+	}
+	if len(node.Children) > 0 {
+		return tree.eachInOrder(node.Children[len(node.Children)-1], f)
+	}
+	return true
+}
+
+// reverseEach visits the subtree rooted at node from right to left and returns false if traversal was stopped
+func (tree *Tree[TKey, TValue]) reverseEach(node *Node[TKey, TValue], f func(key TKey, value TValue) bool) bool {
+	for e := len(node.Entries) - 1; e >= 0; e-- {
+		if e+1 < len(node.Children) && !tree.reverseEach(node.Children[e+1], f) {
+			return false
+		}
+		entry := node.Entries[e]
+		duplicates := tree.duplicates[entry]
+		for d := len(duplicates) - 1; d >= 0; d-- {
+			if !f(entry.Key, duplicates[d]) {
+				return false
+			}
+		}
+		if !f(entry.Key, entry.Value) {
+			return false
+		}
+	}
+	if len(node.Children) > 0 {
+		return tree.reverseEach(node.Children[0], f)
+	}
+	return true
+}
+
+// eachEntry visits the entries of the subtree rooted at node in key order, or in reverse order if reverse is set,
+// without comparing any keys
+func eachEntry[TKey, TValue comparable](node *Node[TKey, TValue], reverse bool, f func(entry *Entry[TKey, TValue])) {
+	last := len(node.Entries) - 1
+	for i := range node.Entries {
+		e, child := i, i
+		if reverse {
+			e, child = last-i, last-i+1
+		}
+		if child < len(node.Children) {
+			eachEntry(node.Children[child], reverse, f)
+		}
+		f(node.Entries[e])
+	}
+	if len(node.Children) > 0 {
+		if reverse {
+			eachEntry(node.Children[0], reverse, f)
+		} else {
+			eachEntry(node.Children[len(node.Children)-1], reverse, f)
+		}
+	}
+}
+
+func (tree *Tree[TKey, TValue]) approxMemoryBytes(node *Node[TKey, TValue]) int {
+	pointerSize := int(unsafe.Sizeof(node))
+	size := int(unsafe.Sizeof(*node)) +
+		cap(node.Entries)*pointerSize +
+		cap(node.Children)*pointerSize +
+		len(node.Entries)*int(unsafe.Sizeof(Entry[TKey, TValue]{}))
+	for _, entry := range node.Entries {
+		size += cap(tree.duplicates[entry]) * int(unsafe.Sizeof(entry.Value))
+	}
+	for _, child := range node.Children {
+		size += tree.approxMemoryBytes(child)
+	}
+	return size
+}
+
+func (tree *Tree[TKey, TValue]) countRange(node *Node[TKey, TValue], lo, hi TKey) int {
+	if node == nil {
+		return 0
+	}
+	count := 0
+	for i, entry := range node.Entries {
+		compareLo, compareHi := tree.Comparator(entry.Key, lo), tree.Comparator(entry.Key, hi)
+		if compareLo > 0 && !tree.isLeaf(node) {
+			count += tree.countRange(node.Children[i], lo, hi)
+		}
+		if compareHi > 0 {
+			return count
 		}
+		if compareLo >= 0 {
+			count += 1 + len(tree.duplicates[entry])
+		}
+	}
+	if !tree.isLeaf(node) {
+		count += tree.countRange(node.Children[len(node.Children)-1], lo, hi)
 	}
+	return count
 }
 
+// height returns the number of levels of the subtree rooted at node (0 for a nil node) by descending the left-most path,
+// as all leaves of a B-tree are at the same depth.
 func (node *Node[TKey, TValue]) height() int {
 	height := 0
 	for ; node != nil; node = node.Children[0] {
@@ -274,6 +689,9 @@ func (tree *Tree[TKey, TValue]) minEntries() int {
 }
 
 func (tree *Tree[TKey, TValue]) middle() int {
+	if tree.splitBias == LeftHeavy {
+		return tree.m / 2
+	}
 	return (tree.m - 1) / 2 // "-1" to favor right nodes to have more keys when splitting
 }
 
@@ -314,34 +732,61 @@ func (tree *Tree[TKey, TValue]) searchRecursively(startNode *Node[TKey, TValue],
 	}
 }
 
-func (tree *Tree[TKey, TValue]) insert(node *Node[TKey, TValue], entry *Entry[TKey, TValue]) (inserted bool) {
+// put inserts the entry and returns the entry it replaced, or nil if none was replaced.
+func (tree *Tree[TKey, TValue]) put(entry *Entry[TKey, TValue]) (replaced *Entry[TKey, TValue]) {
+	if tree.Root == nil {
+		tree.Root = &Node[TKey, TValue]{Entries: []*Entry[TKey, TValue]{entry}, Children: []*Node[TKey, TValue]{}}
+		tree.size++
+		return nil
+	}
+
+	tree.claimRoot()
+	inserted, replaced := tree.insert(tree.Root, entry)
+	if inserted {
+		tree.size++
+	}
+	return replaced
+}
+
+func (tree *Tree[TKey, TValue]) insert(node *Node[TKey, TValue], entry *Entry[TKey, TValue]) (inserted bool, replaced *Entry[TKey, TValue]) {
 	if tree.isLeaf(node) {
 		return tree.insertIntoLeaf(node, entry)
 	}
 	return tree.insertIntoInternal(node, entry)
 }
 
-func (tree *Tree[TKey, TValue]) insertIntoLeaf(node *Node[TKey, TValue], entry *Entry[TKey, TValue]) (inserted bool) {
+func (tree *Tree[TKey, TValue]) insertIntoLeaf(node *Node[TKey, TValue], entry *Entry[TKey, TValue]) (inserted bool, replaced *Entry[TKey, TValue]) {
 	insertPosition, found := tree.search(node, entry.Key)
 	if found {
-		node.Entries[insertPosition] = entry
-		return false
+		return tree.update(node, insertPosition, entry)
 	}
 	// Insert entry's key in the middle of the node
 	node.Entries = append(node.Entries, nil)
 	copy(node.Entries[insertPosition+1:], node.Entries[insertPosition:])
 	node.Entries[insertPosition] = entry
 	tree.split(node)
-	return true
+	return true, nil
 }
 
-func (tree *Tree[TKey, TValue]) insertIntoInternal(node *Node[TKey, TValue], entry *Entry[TKey, TValue]) (inserted bool) {
+func (tree *Tree[TKey, TValue]) insertIntoInternal(node *Node[TKey, TValue], entry *Entry[TKey, TValue]) (inserted bool, replaced *Entry[TKey, TValue]) {
 	insertPosition, found := tree.search(node, entry.Key)
 	if found {
-		node.Entries[insertPosition] = entry
-		return false
+		return tree.update(node, insertPosition, entry)
+	}
+	return tree.insert(tree.claim(node, insertPosition), entry)
+}
+
+// update replaces the entry at the index of the node by the given entry with the same key and returns false
+// and the replaced entry, or adds the entry's value to the existing entry and returns true if the tree allows duplicates.
+func (tree *Tree[TKey, TValue]) update(node *Node[TKey, TValue], index int, entry *Entry[TKey, TValue]) (inserted bool, replaced *Entry[TKey, TValue]) {
+	if tree.multimap {
+		existing := node.Entries[index]
+		tree.duplicates[existing] = append(tree.duplicates[existing], entry.Value)
+		return true, nil
 	}
-	return tree.insert(node.Children[insertPosition], entry)
+	replaced = node.Entries[index]
+	node.Entries[index] = entry
+	return false, replaced
 }
 
 func (tree *Tree[TKey, TValue]) split(node *Node[TKey, TValue]) {
@@ -415,6 +860,112 @@ func (tree *Tree[TKey, TValue]) splitRoot() {
 	tree.Root = newRoot
 }
 
+// absorbDisjoint adds the entries of the other tree, whose keys all sort after (or, if prepend is set, before)
+// the keys of this tree, to the right-most (left-most) leaf. Only the leaf is split when it fills up,
+// after which the edge of the tree is claimed again to find the new right-most (left-most) leaf.
+func (tree *Tree[TKey, TValue]) absorbDisjoint(other *Tree[TKey, TValue], prepend bool, resolve func(key TKey, a, b TValue) TValue) {
+	if tree.Root == nil {
+		tree.Root = &Node[TKey, TValue]{Entries: []*Entry[TKey, TValue]{}, Children: []*Node[TKey, TValue]{}}
+	}
+	leaf := tree.claimEdge(prepend)
+	eachEntry(other.Root, prepend, func(entry *Entry[TKey, TValue]) {
+		values := other.duplicates[entry]
+		entry = copyEntry(entry)
+		// The repeated values of an entry are added along with it
+		if tree.multimap && len(values) > 0 {
+			tree.duplicates[entry] = append([]TValue(nil), values...)
+			tree.size += len(values)
+		} else {
+			for _, value := range values {
+				entry.Value = resolve(entry.Key, entry.Value, value)
+			}
+		}
+		if prepend {
+			leaf.Entries = append(leaf.Entries, nil)
+			copy(leaf.Entries[1:], leaf.Entries)
+			leaf.Entries[0] = entry
+		} else {
+			leaf.Entries = append(leaf.Entries, entry)
+		}
+		tree.size++
+		if tree.shouldSplit(leaf) {
+			tree.split(leaf)
+			leaf = tree.claimEdge(prepend)
+		}
+	})
+}
+
+// claimEdge claims the nodes on the right-most (or, if left is set, left-most) path of the tree
+// and returns the leaf at its end.
+func (tree *Tree[TKey, TValue]) claimEdge(left bool) *Node[TKey, TValue] {
+	tree.claimRoot()
+	node := tree.Root
+	for !tree.isLeaf(node) {
+		if left {
+			node = tree.claim(node, 0)
+		} else {
+			node = tree.claim(node, len(node.Children)-1)
+		}
+	}
+	return node
+}
+
+// claimRoot replaces the root by a copy if it is shared, so that it can be modified in place.
+func (tree *Tree[TKey, TValue]) claimRoot() {
+	if tree.Root.shared {
+		tree.Root = unshare(tree.Root, nil)
+	}
+}
+
+// claim returns the child of the node at the given index, replacing it by a copy first if it is shared,
+// so that it can be modified in place. The node itself must have been claimed already.
+func (tree *Tree[TKey, TValue]) claim(node *Node[TKey, TValue], index int) *Node[TKey, TValue] {
+	child := node.Children[index]
+	if child.shared {
+		child = unshare(child, node)
+		node.Children[index] = child
+	}
+	return child
+}
+
+// searchClaiming searches the key like searchRecursively from the root, claiming every node on the way.
+func (tree *Tree[TKey, TValue]) searchClaiming(key TKey) (node *Node[TKey, TValue], index int, found bool) {
+	if tree.Empty() {
+		return nil, -1, false
+	}
+	tree.claimRoot()
+	node = tree.Root
+	for {
+		index, found = tree.search(node, key)
+		if found {
+			return node, index, true
+		}
+		if tree.isLeaf(node) {
+			return nil, -1, false
+		}
+		node = tree.claim(node, index)
+	}
+}
+
+// unshare copies the node and links the copy to the given parent. The children are then referenced
+// by both the node and its copy, so they are marked as shared.
+func unshare[TKey, TValue comparable](node *Node[TKey, TValue], parent *Node[TKey, TValue]) *Node[TKey, TValue] {
+	copied := &Node[TKey, TValue]{
+		Parent:   parent,
+		Entries:  append([]*Entry[TKey, TValue](nil), node.Entries...),
+		Children: append([]*Node[TKey, TValue](nil), node.Children...),
+	}
+	for _, child := range copied.Children {
+		child.shared = true
+	}
+	return copied
+}
+
+func copyEntry[TKey, TValue comparable](entry *Entry[TKey, TValue]) *Entry[TKey, TValue] {
+	copied := *entry
+	return &copied
+}
+
 func setParent[TKey, TValue comparable](nodes []*Node[TKey, TValue], parent *Node[TKey, TValue]) {
 	for _, node := range nodes {
 		node.Parent = parent
@@ -447,6 +998,46 @@ func (tree *Tree[TKey, TValue]) right(node *Node[TKey, TValue]) *Node[TKey, TVal
 	}
 }
 
+// next returns the node and index of the entry with the smallest key strictly greater than the given key.
+func (tree *Tree[TKey, TValue]) next(key TKey) (next *Node[TKey, TValue], nextIndex int, found bool) {
+	for node := tree.Root; node != nil; {
+		index, found := tree.search(node, key)
+		if found {
+			if !tree.isLeaf(node) {
+				return tree.left(node.Children[index+1]), 0, true
+			}
+			index++
+		}
+		if index < len(node.Entries) {
+			next, nextIndex = node, index
+		}
+		if tree.isLeaf(node) {
+			break
+		}
+		node = node.Children[index]
+	}
+	return next, nextIndex, next != nil
+}
+
+// prev returns the node and index of the entry with the largest key strictly smaller than the given key.
+func (tree *Tree[TKey, TValue]) prev(key TKey) (prev *Node[TKey, TValue], prevIndex int, found bool) {
+	for node := tree.Root; node != nil; {
+		index, found := tree.search(node, key)
+		if found && !tree.isLeaf(node) {
+			right := tree.right(node.Children[index])
+			return right, len(right.Entries) - 1, true
+		}
+		if index > 0 {
+			prev, prevIndex = node, index-1
+		}
+		if tree.isLeaf(node) {
+			break
+		}
+		node = node.Children[index]
+	}
+	return prev, prevIndex, prev != nil
+}
+
 // leftSibling returns the node's left sibling and child index (in parent) if it exists, otherwise (nil,-1)
 // key is any of keys in node (could even be deleted).
 func (tree *Tree[TKey, TValue]) leftSibling(node *Node[TKey, TValue], key TKey) (*Node[TKey, TValue], int) {
@@ -488,7 +1079,10 @@ func (tree *Tree[TKey, TValue]) delete(node *Node[TKey, TValue], index int) {
 	}
 
 	// deleting from an internal node
-	leftLargestNode := tree.right(node.Children[index]) // largest node in the left sub-tree (assumed to exist)
+	leftLargestNode := tree.claim(node, index) // largest node in the left sub-tree (internal nodes have one child more than entries)
+	for !tree.isLeaf(leftLargestNode) {
+		leftLargestNode = tree.claim(leftLargestNode, len(leftLargestNode.Children)-1)
+	}
 	leftLargestEntryIndex := len(leftLargestNode.Entries) - 1
 	node.Entries[index] = leftLargestNode.Entries[leftLargestEntryIndex]
 	deletedKey := leftLargestNode.Entries[leftLargestEntryIndex].Key
@@ -507,6 +1101,7 @@ func (tree *Tree[TKey, TValue]) rebalance(node *Node[TKey, TValue], deletedKey T
 	// try to borrow from left sibling
 	leftSibling, leftSiblingIndex := tree.leftSibling(node, deletedKey)
 	if leftSibling != nil && len(leftSibling.Entries) > tree.minEntries() {
+		leftSibling = tree.claim(node.Parent, leftSiblingIndex)
 		// rotate right
 		node.Entries = append([]*Entry[TKey, TValue]{node.Parent.Entries[leftSiblingIndex]}, node.Entries...) // prepend parent's separator entry to node's entries
 		node.Parent.Entries[leftSiblingIndex] = leftSibling.Entries[len(leftSibling.Entries)-1]
@@ -523,6 +1118,7 @@ func (tree *Tree[TKey, TValue]) rebalance(node *Node[TKey, TValue], deletedKey T
 	// try to borrow from right sibling
 	rightSibling, rightSiblingIndex := tree.rightSibling(node, deletedKey)
 	if rightSibling != nil && len(rightSibling.Entries) > tree.minEntries() {
+		rightSibling = tree.claim(node.Parent, rightSiblingIndex)
 		// rotate left
 		node.Entries = append(node.Entries, node.Parent.Entries[rightSiblingIndex-1]) // append parent's separator entry to node's entries
 		node.Parent.Entries[rightSiblingIndex-1] = rightSibling.Entries[0]
@@ -539,20 +1135,22 @@ func (tree *Tree[TKey, TValue]) rebalance(node *Node[TKey, TValue], deletedKey T
 	// merge with siblings
 	if rightSibling != nil {
 		// merge with right sibling
+		rightSibling = tree.claim(node.Parent, rightSiblingIndex)
 		node.Entries = append(node.Entries, node.Parent.Entries[rightSiblingIndex-1])
 		node.Entries = append(node.Entries, rightSibling.Entries...)
 		deletedKey = node.Parent.Entries[rightSiblingIndex-1].Key
 		tree.deleteEntry(node.Parent, rightSiblingIndex-1)
-		tree.appendChildren(node.Parent.Children[rightSiblingIndex], node)
+		tree.appendChildren(rightSibling, node)
 		tree.deleteChild(node.Parent, rightSiblingIndex)
 	} else if leftSibling != nil {
 		// merge with left sibling
+		leftSibling = tree.claim(node.Parent, leftSiblingIndex)
 		entries := append([]*Entry[TKey, TValue](nil), leftSibling.Entries...)
 		entries = append(entries, node.Parent.Entries[leftSiblingIndex])
 		node.Entries = append(entries, node.Entries...)
 		deletedKey = node.Parent.Entries[leftSiblingIndex].Key
 		tree.deleteEntry(node.Parent, leftSiblingIndex)
-		tree.prependChildren(node.Parent.Children[leftSiblingIndex], node)
+		tree.prependChildren(leftSibling, node)
 		tree.deleteChild(node.Parent, leftSiblingIndex)
 	}
 
@@ -592,3 +1190,55 @@ func (tree *Tree[TKey, TValue]) deleteChild(node *Node[TKey, TValue], index int)
 	node.Children[len(node.Children)-1] = nil
 	node.Children = node.Children[:len(node.Children)-1]
 }
+
+// validate checks the subtree rooted at node whose keys must lie strictly between the lower and upper entries (if any)
+// and returns the number of elements it holds.
+func (tree *Tree[TKey, TValue]) validate(node *Node[TKey, TValue], lower, upper *Entry[TKey, TValue], depth int, leafDepth *int) (int, error) {
+	if len(node.Entries) > tree.maxEntries() {
+		return 0, fmt.Errorf("node %v has more than %d entries", node.Entries, tree.maxEntries())
+	}
+	if (node != tree.Root && len(node.Entries) < tree.minEntries()) || len(node.Entries) == 0 {
+		return 0, fmt.Errorf("node %v has fewer than %d entries", node.Entries, tree.minEntries())
+	}
+	size := 0
+	previous := lower
+	for _, entry := range node.Entries {
+		if previous != nil && tree.Comparator(previous.Key, entry.Key) >= 0 {
+			return 0, fmt.Errorf("entry %v is not greater than %v", entry, previous)
+		}
+		previous = entry
+		size += 1 + len(tree.duplicates[entry])
+	}
+	if upper != nil && tree.Comparator(previous.Key, upper.Key) >= 0 {
+		return 0, fmt.Errorf("entry %v is not less than %v", previous, upper)
+	}
+	if tree.isLeaf(node) {
+		if *leafDepth == -1 {
+			*leafDepth = depth
+		} else if *leafDepth != depth {
+			return 0, fmt.Errorf("leaf %v is at depth %d, expected %d", node.Entries, depth, *leafDepth)
+		}
+		return size, nil
+	}
+	if len(node.Children) != len(node.Entries)+1 {
+		return 0, fmt.Errorf("node %v has %d children", node.Entries, len(node.Children))
+	}
+	for i, child := range node.Children {
+		if !child.shared && child.Parent != node {
+			return 0, fmt.Errorf("node %v has an inconsistent parent link", child.Entries)
+		}
+		childLower, childUpper := lower, upper
+		if i > 0 {
+			childLower = node.Entries[i-1]
+		}
+		if i < len(node.Entries) {
+			childUpper = node.Entries[i]
+		}
+		childSize, err := tree.validate(child, childLower, childUpper, depth+1, leafDepth)
+		if err != nil {
+			return 0, err
+		}
+		size += childSize
+	}
+	return size, nil
+}
@@ -5,10 +5,15 @@
 package btree
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/rand"
+	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/a234567894/gods/utils"
 )
 
 func TestBTreeGet1(t *testing.T) {
@@ -356,6 +361,187 @@ func TestBTreePut4(t *testing.T) {
 	assertValidTreeNode(t, tree.Root.Children[2].Children[1], 1, 0, []int{6}, true)
 }
 
+func TestBTreePutWithPrevious(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	for i := 1; i <= 10; i++ {
+		if old, replaced := tree.PutWithPrevious(i, fmt.Sprint(i)); old != "" || replaced {
+			t.Errorf("Got %v %v expected %v %v", old, replaced, "", false)
+		}
+	}
+	for i := 1; i <= 10; i++ {
+		if old, replaced := tree.PutWithPrevious(i, "x"); old != fmt.Sprint(i) || !replaced {
+			t.Errorf("Got %v %v expected %v %v", old, replaced, i, true)
+		}
+	}
+	if actualValue, expectedValue := tree.Size(), 10; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, _ := tree.Get(7); actualValue != "x" {
+		t.Errorf("Got %v expected %v", actualValue, "x")
+	}
+
+	multimap := NewWithOptions[int, string](3, utils.IntComparator, Options{AllowDuplicates: true})
+	multimap.PutWithPrevious(1, "a")
+	if old, replaced := multimap.PutWithPrevious(1, "b"); old != "" || replaced {
+		t.Errorf("Got %v %v expected %v %v", old, replaced, "", false)
+	}
+	if actualValue, expectedValue := fmt.Sprint(multimap.GetAll(1)), "[a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreePutPersistent(t *testing.T) {
+	versions := []*Tree[int, int]{NewWithIntComparator[int, int](3)}
+	for i := 1; i <= 50; i++ {
+		versions = append(versions, versions[i-1].PutPersistent(i%7*10+i, i))
+	}
+	versions = append(versions, versions[50].PutPersistent(1, -1))
+	for i, version := range versions[:51] {
+		if actualValue, expectedValue := version.Size(), i; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err := version.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	if _, found := versions[50].Get(1); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+	if actualValue, _ := versions[51].Get(1); actualValue != -1 {
+		t.Errorf("Got %v expected %v", actualValue, -1)
+	}
+
+	// an old version is unaffected by modifications of a newer one
+	latest := versions[51]
+	for _, key := range latest.Keys() {
+		latest.Remove(key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(versions[3].Keys()), "[11 22 33]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	multimap := NewWithOptions[int, string](3, utils.IntComparator, Options{AllowDuplicates: true})
+	multimap.Put(1, "a")
+	next := multimap.PutPersistent(1, "b")
+	if actualValue, expectedValue := fmt.Sprint(multimap.GetAll(1), next.GetAll(1)), "[a] [a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreePutPersistentSharing(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	for i := 1; i <= 100; i++ {
+		tree.Put(i, fmt.Sprint(i))
+	}
+	keys, values := fmt.Sprint(tree.Keys()), fmt.Sprint(tree.Values())
+	version := tree.PutPersistent(1000, "1000")
+
+	// only the right-most path is copied, all subtrees left of it are shared
+	if tree.Root == version.Root {
+		t.Errorf("Got %v expected %v", "shared root", "copied root")
+	}
+	for node, copied := tree.Root, version.Root; len(node.Children) > 0; node, copied = node.Children[len(node.Children)-1], copied.Children[len(copied.Children)-1] {
+		for i := 0; i < len(node.Children)-1; i++ {
+			if node.Children[i] != copied.Children[i] {
+				t.Errorf("Got %v expected %v", "copied subtree", "shared subtree")
+			}
+		}
+		if node.Children[len(node.Children)-1] == copied.Children[len(copied.Children)-1] {
+			t.Errorf("Got %v expected %v", "shared path", "copied path")
+		}
+	}
+	if err := version.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	// modifications of either version through shared nodes are not visible in the other
+	for i := 1; i <= 100; i += 3 {
+		version.Remove(i)
+	}
+	version.Put(50, "fifty")
+	if entry, _ := version.GetEntry(51); entry != nil {
+		entry.Value = "modified"
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys(), tree.Values()), fmt.Sprint(keys, " ", values); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, _ := version.Get(51); actualValue != "51" {
+		t.Errorf("Got %v expected %v", actualValue, "51")
+	}
+	tree.Remove(2)
+	tree.Put(3, "three")
+	if actualValue, _ := version.Get(2); actualValue != "2" {
+		t.Errorf("Got %v expected %v", actualValue, "2")
+	}
+	if actualValue, _ := version.Get(3); actualValue != "3" {
+		t.Errorf("Got %v expected %v", actualValue, "3")
+	}
+	if actualValue, _ := version.Get(50); actualValue != "fifty" {
+		t.Errorf("Got %v expected %v", actualValue, "fifty")
+	}
+	if actualValue, expectedValue := version.Size(), 67; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Size(), 99; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, v := range []*Tree[int, string]{tree, version} {
+		if err := v.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+}
+
+func TestBTreePutPersistentRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for order := 3; order <= 6; order++ {
+		versions := []*Tree[int, int]{NewWithIntComparator[int, int](order)}
+		expected := []map[int]int{{}}
+		for i := 0; i < 500; i++ {
+			v := r.Intn(len(versions))
+			key := r.Intn(100)
+			switch r.Intn(3) {
+			case 0:
+				versions = append(versions, versions[v].PutPersistent(key, i))
+				next := map[int]int{}
+				for k, value := range expected[v] {
+					next[k] = value
+				}
+				next[key] = i
+				expected = append(expected, next)
+			case 1:
+				versions[v].Put(key, i)
+				expected[v][key] = i
+			default:
+				versions[v].Remove(key)
+				delete(expected[v], key)
+			}
+		}
+		for v, version := range versions {
+			if err := version.Validate(); err != nil {
+				t.Errorf("Got error %v", err)
+			}
+			if actualValue, expectedValue := version.Size(), len(expected[v]); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			previous := -1
+			for it := version.Iterator(); it.Next(); {
+				if value, found := expected[v][it.Key()]; !found || value != it.Value() || it.Key() <= previous {
+					t.Errorf("Got %v:%v expected %v:%v", it.Key(), it.Value(), it.Key(), value)
+				}
+				previous = it.Key()
+			}
+			count, it := 0, version.Iterator()
+			for it.End(); it.Prev(); {
+				count++
+			}
+			if actualValue, expectedValue := count, len(expected[v]); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+}
+
 func TestBTreeRemove1(t *testing.T) {
 	// empty
 	tree := NewWithIntComparator[int, string](3)
@@ -610,6 +796,86 @@ func TestBTreeRemove9(t *testing.T) {
 	}
 }
 
+func TestBTreeHeightEmptyAndLarge(t *testing.T) {
+	tree := NewWithIntComparator[int, int](5)
+	if actualValue, expectedValue := tree.Height(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 0; i < 1000; i++ {
+		tree.Put(i, i)
+	}
+	// a B-tree of order 5 holding 1000 keys has between log5(1001) and 1+log3(500.5) levels
+	if actualValue := tree.Height(); actualValue < 5 || actualValue > 6 {
+		t.Errorf("Got %v expected %v", actualValue, "5 or 6")
+	}
+	tree.Clear()
+	if actualValue, expectedValue := tree.Height(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Put(1, 1)
+	if actualValue, expectedValue := tree.Height(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeRemoveRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for order := 3; order <= 7; order++ {
+		tree := NewWithIntComparator[int, int](order)
+		expected := map[int]bool{}
+		for i := 0; i < 5000; i++ {
+			key := r.Intn(500)
+			if r.Intn(3) == 0 {
+				tree.Remove(key)
+				delete(expected, key)
+			} else {
+				tree.Put(key, i)
+				expected[key] = true
+			}
+			if err := tree.Validate(); err != nil {
+				t.Errorf("Got error %v", err)
+				return
+			}
+		}
+		keys := tree.Keys()
+		if actualValue, expectedValue := len(keys), len(expected); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i := 1; i < len(keys); i++ {
+			if keys[i-1] >= keys[i] {
+				t.Errorf("order %d: keys not sorted at %d: %v >= %v", order, i, keys[i-1], keys[i])
+			}
+		}
+		for _, key := range r.Perm(500) {
+			tree.Remove(key)
+			if err := tree.Validate(); err != nil {
+				t.Errorf("Got error %v", err)
+				return
+			}
+		}
+		if actualValue := tree.Empty(); actualValue != true {
+			t.Errorf("Got %v expected %v", actualValue, true)
+		}
+	}
+}
+
+func TestBTreeValidate(t *testing.T) {
+	tree := NewWithIntComparator[int, int](3)
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		tree.Put(i, i)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	tree.Root.Entries[0], tree.Root.Children[0].Entries[0] = tree.Root.Children[0].Entries[0], tree.Root.Entries[0]
+	if err := tree.Validate(); err == nil {
+		t.Errorf("Got %v expected an error", err)
+	}
+}
+
 func TestBTreeHeight(t *testing.T) {
 	tree := NewWithIntComparator[int, int](3)
 	if actualValue, expectedValue := tree.Height(), 0; actualValue != expectedValue {
@@ -697,6 +963,34 @@ func TestBTreeLeftAndRight(t *testing.T) {
 	}
 }
 
+func TestBTreeKeysIntoValuesInto(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	tree.Put(2, "b")
+	tree.Put(1, "a")
+	tree.Put(3, "c")
+
+	keys := tree.KeysInto(nil)
+	if actualValue, expectedValue := fmt.Sprint(keys), "[1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Remove(2)
+	reused := tree.KeysInto(keys)
+	if actualValue, expectedValue := fmt.Sprint(reused), "[1 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if &reused[0] != &keys[0] {
+		t.Errorf("Buffer should have been reused")
+	}
+
+	values := tree.ValuesInto(make([]string, 0, 1))
+	if actualValue, expectedValue := fmt.Sprint(values), "[a c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if allocs := testing.AllocsPerRun(10, func() { values = tree.ValuesInto(values) }); allocs != 0 {
+		t.Errorf("Got %v expected %v", allocs, 0)
+	}
+}
+
 func TestBTreeIteratorValuesAndKeys(t *testing.T) {
 	tree := NewWithIntComparator[int, string](4)
 	tree.Put(4, "d")
@@ -718,6 +1012,285 @@ func TestBTreeIteratorValuesAndKeys(t *testing.T) {
 	}
 }
 
+func TestBTreeEachInOrder(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	tree.EachInOrder(func(key int, value string) bool {
+		t.Errorf("Shouldn't iterate on empty tree")
+		return true
+	})
+
+	for i := 20; i >= 1; i-- {
+		tree.Put(i, fmt.Sprintf("%d", i))
+	}
+
+	keys := []int{}
+	tree.EachInOrder(func(key int, value string) bool {
+		if actualValue, expectedValue := value, fmt.Sprintf("%d", key); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		keys = append(keys, key)
+		return true
+	})
+	if actualValue, expectedValue := fmt.Sprint(keys), fmt.Sprint(tree.Keys()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// early termination
+	keys = []int{}
+	tree.EachInOrder(func(key int, value string) bool {
+		keys = append(keys, key)
+		return key < 5
+	})
+	if actualValue, expectedValue := fmt.Sprint(keys), "[1 2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeReverseEach(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	tree.ReverseEach(func(key int, value string) bool {
+		t.Errorf("Shouldn't iterate on empty tree")
+		return true
+	})
+
+	for i := 1; i <= 20; i++ {
+		tree.Put(i, fmt.Sprintf("%d", i))
+	}
+
+	keys := []int{}
+	tree.ReverseEach(func(key int, value string) bool {
+		if actualValue, expectedValue := value, fmt.Sprintf("%d", key); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		keys = append(keys, key)
+		return true
+	})
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 19 18 17 16 15 14 13 12 11 10 9 8 7 6 5 4 3 2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// early termination
+	keys = []int{}
+	tree.ReverseEach(func(key int, value string) bool {
+		keys = append(keys, key)
+		return key > 15
+	})
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 19 18 17 16 15]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeAbsorbSorted(t *testing.T) {
+	sum := func(key int, a, b int) int { return a + b }
+
+	// overlapping ranges
+	tree := NewWithIntComparator[int, int](3)
+	other := NewWithIntComparator[int, int](5)
+	for i := 1; i <= 10; i++ {
+		tree.Put(i, i)
+	}
+	for i := 6; i <= 15; i++ {
+		other.Put(i, 100)
+	}
+	tree.AbsorbSorted(other, sum)
+	assertValidTree(t, tree, 15)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4 5 6 7 8 9 10 11 12 13 14 15]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[1 2 3 4 5 106 107 108 109 110 100 100 100 100 100]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := other.Size(); actualValue != 10 {
+		t.Errorf("Got %v expected %v", actualValue, 10)
+	}
+
+	// disjoint ranges, other above and below
+	tree = NewWithIntComparator[int, int](5)
+	for i := 10; i < 20; i++ {
+		tree.Put(i, i)
+	}
+	above := NewWithIntComparator[int, int](3)
+	below := NewWithIntComparator[int, int](3)
+	for i := 0; i < 10; i++ {
+		above.Put(i+20, i+20)
+		below.Put(i, i)
+	}
+	noConflicts := func(key int, a, b int) int {
+		t.Errorf("Should not resolve on disjoint ranges")
+		return a
+	}
+	tree.AbsorbSorted(above, noConflicts)
+	tree.AbsorbSorted(below, noConflicts)
+	tree.AbsorbSorted(NewWithIntComparator[int, int](3), noConflicts)
+	if actualValue := tree.Size(); actualValue != 30 {
+		t.Errorf("Got %v expected %v", actualValue, 30)
+	}
+	for i, key := range tree.Keys() {
+		if value, found := tree.Get(key); key != i || value != i || !found {
+			t.Errorf("Got %v:%v expected %v:%v", key, value, i, i)
+		}
+	}
+
+	// absorbing into an empty tree
+	empty := NewWithIntComparator[int, int](4)
+	empty.AbsorbSorted(tree, noConflicts)
+	if actualValue, expectedValue := fmt.Sprint(empty.Keys()), fmt.Sprint(tree.Keys()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeAbsorbSortedDisjoint(t *testing.T) {
+	noConflicts := func(key int, a, b int) int {
+		t.Errorf("Should not resolve on disjoint ranges")
+		return a
+	}
+	for _, order := range []int{3, 5} {
+		comparisons := 0
+		comparator := func(a, b interface{}) int {
+			comparisons++
+			return utils.IntComparator(a, b)
+		}
+		tree := NewWith[int, int](order, comparator)
+		above := NewWith[int, int](order, comparator)
+		below := NewWith[int, int](order, comparator)
+		for i := 0; i < 1000; i++ {
+			tree.Put(i+1000, i+1000)
+			above.Put(i+2000, i+2000)
+			below.Put(i, i)
+		}
+
+		// entries are added to the edges of the tree instead of being searched for, which would take
+		// several comparisons per entry
+		comparisons = 0
+		tree.AbsorbSorted(above, noConflicts)
+		tree.AbsorbSorted(below, noConflicts)
+		if actualValue, expectedValue := comparisons, 2*(above.Size()+below.Size()); actualValue > expectedValue {
+			t.Errorf("Got %v expected at most %v", actualValue, expectedValue)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := tree.Size(), 3000; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, key := range tree.Keys() {
+			if value, found := tree.Get(key); key != i || value != i || !found {
+				t.Errorf("Got %v:%v expected %v:%v", key, value, i, i)
+			}
+		}
+		if actualValue, expectedValue := above.Size()+below.Size(), 2000; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	// a previous version sharing nodes with the tree is left unchanged
+	tree := NewWithIntComparator[int, int](3)
+	for i := 0; i < 100; i++ {
+		tree.Put(i, i)
+	}
+	version := tree.PutPersistent(100, 100)
+	above := NewWithIntComparator[int, int](3)
+	for i := 200; i < 300; i++ {
+		above.Put(i, i)
+	}
+	version.AbsorbSorted(above, noConflicts)
+	if err := version.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := version.Size(), 201; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Size(), 100; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.RightKey(), len(tree.Keys())), "99 100"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// repeated values are added along with their entry, or resolved if the tree does not allow duplicates
+	multimap := NewWithOptions[int, int](3, utils.IntComparator, Options{AllowDuplicates: true})
+	multimap.Put(1, 10)
+	multimap.Put(1, 11)
+	multimap.Put(2, 20)
+	multimap.Put(2, 21)
+	multimap.Put(2, 22)
+	duplicates := NewWithOptions[int, int](3, utils.IntComparator, Options{AllowDuplicates: true})
+	duplicates.Put(0, 0)
+	duplicates.AbsorbSorted(multimap, noConflicts)
+	if actualValue, expectedValue := fmt.Sprint(duplicates.Size(), duplicates.GetAll(2)), "6 [20 21 22]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	unique := NewWithIntComparator[int, int](3)
+	unique.Put(3, 30)
+	unique.AbsorbSorted(multimap, func(key int, a, b int) int { return a + b })
+	if actualValue, expectedValue := fmt.Sprint(unique.Keys(), unique.Values()), "[1 2 3] [21 63 30]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeApproxMemoryBytes(t *testing.T) {
+	tree := NewWithIntComparator[int, int](5)
+	if actualValue := tree.ApproxMemoryBytes(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+
+	tree.Put(1, 1)
+	if actualValue := tree.ApproxMemoryBytes(); actualValue <= 0 {
+		t.Errorf("Got %v expected positive estimate", actualValue)
+	}
+
+	for i := 0; i < 1000; i++ {
+		tree.Put(i, i)
+	}
+	small := tree.ApproxMemoryBytes()
+	for i := 1000; i < 2000; i++ {
+		tree.Put(i, i)
+	}
+	large := tree.ApproxMemoryBytes()
+	if ratio := float64(large) / float64(small); ratio < 1.5 || ratio > 2.5 {
+		t.Errorf("Got ratio %v expected roughly %v", ratio, 2)
+	}
+
+	tree.Clear()
+	if actualValue := tree.ApproxMemoryBytes(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestBTreeNextAndPrev(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	if entry, found := tree.Next(1); entry != nil || found {
+		t.Errorf("Got %v expected %v", entry, nil)
+	}
+	if entry, found := tree.Prev(1); entry != nil || found {
+		t.Errorf("Got %v expected %v", entry, nil)
+	}
+
+	for i := 2; i <= 40; i += 2 {
+		tree.Put(i, fmt.Sprintf("%d", i))
+	}
+
+	for key := 0; key <= 42; key++ {
+		expectedNext := key + 2 - key%2
+		if entry, found := tree.Next(key); expectedNext > 40 {
+			if entry != nil || found {
+				t.Errorf("Got %v expected %v for next of %v", entry, nil, key)
+			}
+		} else if !found || entry.Key != expectedNext || entry.Value != fmt.Sprintf("%d", expectedNext) {
+			t.Errorf("Got %v expected %v for next of %v", entry, expectedNext, key)
+		}
+
+		expectedPrev := key - 2 + key%2
+		if entry, found := tree.Prev(key); expectedPrev < 2 {
+			if entry != nil || found {
+				t.Errorf("Got %v expected %v for prev of %v", entry, nil, key)
+			}
+		} else if !found || entry.Key != expectedPrev {
+			t.Errorf("Got %v expected %v for prev of %v", entry, expectedPrev, key)
+		}
+	}
+}
+
 func TestBTreeIteratorNextOnEmpty(t *testing.T) {
 	tree := NewWithIntComparator[int, string](3)
 	it := tree.Iterator()
@@ -1001,22 +1574,184 @@ func TestBTreeIteratorLast(t *testing.T) {
 	}
 }
 
-func TestBTreeSearch(t *testing.T) {
-	{
-		tree := NewWithIntComparator[int, int](3)
-		tree.Root = &Node[int, int]{Entries: []*Entry[int, int]{}, Children: make([]*Node[int, int], 0)}
-		tests := [][]interface{}{
-			{0, 0, false},
-		}
-		for _, test := range tests {
-			index, found := tree.search(tree.Root, test[0].(int))
-			if actualValue, expectedValue := index, test[1]; actualValue != expectedValue {
-				t.Errorf("Got %v expected %v", actualValue, expectedValue)
-			}
-			if actualValue, expectedValue := found, test[2]; actualValue != expectedValue {
-				t.Errorf("Got %v expected %v", actualValue, expectedValue)
-			}
-		}
+func TestBTreeSplitBias(t *testing.T) {
+	right := NewWithOptions[int, int](4, utils.IntComparator, Options{})
+	left := NewWithOptions[int, int](4, utils.IntComparator, Options{SplitBias: LeftHeavy})
+	for i := 1; i <= 4; i++ {
+		right.Put(i, i)
+		left.Put(i, i)
+	}
+	if actualValue, expectedValue := fmt.Sprint(right.Root.Entries[0].Key, len(right.Root.Children[0].Entries), len(right.Root.Children[1].Entries)), "2 1 2"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(left.Root.Entries[0].Key, len(left.Root.Children[0].Entries), len(left.Root.Children[1].Entries)), "3 2 1"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	var countNodes func(node *Node[int, int]) int
+	countNodes = func(node *Node[int, int]) int {
+		count := 1
+		for _, child := range node.Children {
+			count += countNodes(child)
+		}
+		return count
+	}
+	for i := 5; i <= 1000; i++ {
+		right.Put(i, i)
+		left.Put(i, i)
+	}
+	if actualValue, expectedValue := countNodes(left.Root), countNodes(right.Root); actualValue >= expectedValue {
+		t.Errorf("Got %v expected less than %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(left.Keys()), fmt.Sprint(right.Keys()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 1; i <= 1000; i += 2 {
+		left.Remove(i)
+	}
+	if actualValue, expectedValue := left.Size(), 500; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := left.Get(500); actualValue != 500 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 500)
+	}
+}
+
+func TestBTreeCountRange(t *testing.T) {
+	tree := NewWithIntComparator[int, int](3)
+	if actualValue := tree.CountRange(0, 10); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	for i := 0; i < 200; i += 2 {
+		tree.Put(i, i)
+	}
+	bruteForce := func(lo, hi int) int {
+		count := 0
+		for _, key := range tree.Keys() {
+			if lo <= key && key <= hi {
+				count++
+			}
+		}
+		return count
+	}
+	for lo := -3; lo < 203; lo += 7 {
+		for hi := lo - 5; hi < 205; hi += 11 {
+			if actualValue, expectedValue := tree.CountRange(lo, hi), bruteForce(lo, hi); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v for [%v, %v]", actualValue, expectedValue, lo, hi)
+			}
+		}
+	}
+	// both bounds are inclusive
+	if actualValue := tree.CountRange(10, 20); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+	if actualValue := tree.CountRange(10, 10); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue := tree.CountRange(11, 11); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestBTreeAllowDuplicates(t *testing.T) {
+	tree := NewWithOptions[int, string](3, utils.IntComparator, Options{AllowDuplicates: true})
+	for i := 1; i <= 20; i++ {
+		tree.Put(i%5, fmt.Sprint(i))
+	}
+	if actualValue, expectedValue := tree.Size(), 20; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.GetAll(2)), "[2 7 12 17]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := tree.GetAll(5); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	if actualValue, found := tree.Get(2); actualValue != "2" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "2")
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[0 0 0 0 1 1 1 1 2 2 2 2 3 3 3 3 4 4 4 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()[:8]), "[5 10 15 20 1 6 11 16]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.CountRange(1, 2), 8; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	values := ""
+	tree.ReverseEach(func(key int, value string) bool {
+		values += value + " "
+		return key > 3
+	})
+	if actualValue, expectedValue := values, "19 14 9 4 18 "; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it := tree.Iterator()
+	it.End()
+	values = ""
+	for i := 0; i < 5 && it.Prev(); i++ {
+		values += it.Value() + " "
+	}
+	if actualValue, expectedValue := values, "19 14 9 4 18 "; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue, expectedValue := tree.RemoveKey(2, false), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.GetAll(2)), "[2 7 12]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.RemoveKey(2, true), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Remove(3)
+	if actualValue, expectedValue := tree.Size(), 12; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[0 0 0 0 1 1 1 1 4 4 4 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.RemoveKey(3, true), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Clear()
+	tree.Put(1, "a")
+	if actualValue, expectedValue := fmt.Sprint(tree.GetAll(1)), "[a]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// the default remains single-valued
+	single := NewWithIntComparator[int, string](3)
+	single.Put(1, "a")
+	single.Put(1, "b")
+	if actualValue, expectedValue := fmt.Sprint(single.Size(), single.GetAll(1)), "1 [b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := single.RemoveKey(1, false), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeSearch(t *testing.T) {
+	{
+		tree := NewWithIntComparator[int, int](3)
+		tree.Root = &Node[int, int]{Entries: []*Entry[int, int]{}, Children: make([]*Node[int, int], 0)}
+		tests := [][]interface{}{
+			{0, 0, false},
+		}
+		for _, test := range tests {
+			index, found := tree.search(tree.Root, test[0].(int))
+			if actualValue, expectedValue := index, test[1]; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			if actualValue, expectedValue := found, test[2]; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
 	}
 	{
 		tree := NewWithIntComparator[int, int](3)
@@ -1066,6 +1801,174 @@ func assertValidTreeNode[TKey, TValue comparable](t *testing.T, node *Node[TKey,
 	}
 }
 
+func TestBTreeContains(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	if actualValue := tree.Contains(1); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	for i := 1; i <= 10; i++ {
+		tree.Put(i, "")
+	}
+	tree.Remove(5)
+	for i := 0; i <= 11; i++ {
+		if actualValue, expectedValue := tree.Contains(i), i >= 1 && i <= 10 && i != 5; actualValue != expectedValue {
+			t.Errorf("Contains(%v): Got %v expected %v", i, actualValue, expectedValue)
+		}
+	}
+}
+
+func TestBTreeGetEntryAndIteratorAt(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	if entry, found := tree.GetEntry(1); entry != nil || found {
+		t.Errorf("Got %v expected %v", entry, nil)
+	}
+	if it, found := tree.IteratorAt(1); found || it.Next() {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+
+	for i := 1; i <= 9; i++ {
+		tree.Put(i, fmt.Sprint(i))
+	}
+	if entry, found := tree.GetEntry(4); !found || entry.Key != 4 || entry.Value != "4" {
+		t.Errorf("Got %v expected %v", entry, "4:4")
+	}
+	if entry, found := tree.GetEntry(10); entry != nil || found {
+		t.Errorf("Got %v expected %v", entry, nil)
+	}
+
+	for key := 1; key <= 9; key++ {
+		it, found := tree.IteratorAt(key)
+		if !found || it.Key() != key {
+			t.Errorf("Got %v expected %v", it.Key(), key)
+		}
+		keys := ""
+		for it.Next() {
+			keys += it.Value()
+		}
+		if actualValue, expectedValue := keys, "123456789"[key:]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		it, _ = tree.IteratorAt(key)
+		keys = ""
+		for it.Prev() {
+			keys += it.Value()
+		}
+		if actualValue, expectedValue := keys, "87654321"[9-key:]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	it, found := tree.IteratorAt(0)
+	if found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+	if it.Prev(); it.Key() != 9 {
+		t.Errorf("Got %v expected %v", it.Key(), 9)
+	}
+}
+
+func TestBTreeIteratorIndex(t *testing.T) {
+	tree := NewWithIntComparator[int, int](3)
+	for i := 10; i >= 1; i-- {
+		tree.Put(i, i)
+	}
+	it := tree.Iterator()
+	if actualValue, expectedValue := it.Index(), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 0; it.Next(); i++ {
+		if actualValue, expectedValue := it.Index(), i; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := it.Index(), 10; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 9; it.Prev(); i-- {
+		if actualValue, expectedValue := it.Index(), i; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := it.Index(), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Last()
+	if actualValue, expectedValue := it.Index(), 9; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.First()
+	if actualValue, expectedValue := it.Index(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it, _ = tree.IteratorAt(7)
+	if actualValue, expectedValue := it.Index(), 6; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Next()
+	if actualValue, expectedValue := it.Index(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	multimap := NewWithOptions[int, int](3, utils.IntComparator, Options{AllowDuplicates: true})
+	for _, key := range []int{1, 2, 2, 2, 3} {
+		multimap.Put(key, key)
+	}
+	it, _ = multimap.IteratorAt(3)
+	if actualValue, expectedValue := it.Index(), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Prev()
+	if actualValue, expectedValue := it.Index(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// the index is computed lazily, also after moving the iterator first
+	it, _ = multimap.IteratorAt(2)
+	it.Next()
+	it.Next()
+	if actualValue, expectedValue := it.Index(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it, _ = multimap.IteratorAt(3)
+	it.Prev()
+	it.Prev()
+	if actualValue, expectedValue := it.Index(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it, _ = multimap.IteratorAt(3)
+	it.Next()
+	if actualValue, expectedValue := it.Index(), 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeIteratorPrevMirrorsNext(t *testing.T) {
+	for _, order := range []int{3, 4, 5} {
+		tree := NewWithIntComparator[int, int](order)
+		for i := 0; i < 100; i++ {
+			tree.Put((i*37)%100, i)
+		}
+		forward := []int{}
+		it := tree.Iterator()
+		for it.Next() {
+			forward = append(forward, it.Key())
+		}
+		backward := []int{}
+		it.End()
+		for it.Prev() {
+			backward = append(backward, it.Key())
+		}
+		if actualValue, expectedValue := len(backward), len(forward); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			continue
+		}
+		for i := range forward {
+			if actualValue, expectedValue := backward[len(backward)-1-i], forward[i]; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+}
+
 func TestBTreeIteratorNextTo(t *testing.T) {
 	// Sample seek function, i.e. string starting with "b"
 	seek := func(index int, value string) bool {
@@ -1213,6 +2116,86 @@ func TestBTreeSerialization(t *testing.T) {
 	}
 }
 
+func TestBTreeSerializationIntKeys(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	for _, key := range []int{10, 2, 33, 4, 1} {
+		tree.Put(key, fmt.Sprint("v", key))
+	}
+	data, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `[{"key":1,"value":"v1"},{"key":2,"value":"v2"},{"key":4,"value":"v4"},{"key":10,"value":"v10"},{"key":33,"value":"v33"}]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	other := NewWithIntComparator[int, string](3)
+	if err := other.FromJSON([]byte(`[{"key":33,"value":"v33"},{"key":1,"value":"v1"},{"key":10,"value":"v10"}]`)); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys()), "[1 10 33]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// legacy object form with stringified keys
+	if err := other.FromJSON([]byte(`{"10":"a","9":"b"}`)); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys(), other.Values()), "[9 10] [b a]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeStreamJSON(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	for i := 20; i > 0; i-- {
+		tree.Put(i, fmt.Sprint("v", i))
+	}
+	var buf bytes.Buffer
+	if err := tree.WriteJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	data, _ := tree.ToJSON()
+	if actualValue, expectedValue := buf.String(), string(data); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	other := NewWithIntComparator[int, string](3)
+	other.Put(100, "stale")
+	if err := other.ReadJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys(), other.Values()), fmt.Sprint(tree.Keys(), tree.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	empty := NewWithIntComparator[int, string](3)
+	buf.Reset()
+	if err := empty.WriteJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := buf.String(), "[]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// the object form accepted by FromJSON is read in input order
+	if err := other.ReadJSON(strings.NewReader(`{"2":"b","1":"a"}`)); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys(), other.Values()), "[1 2] [a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := other.ReadJSON(strings.NewReader(`"a"`)); err == nil {
+		t.Errorf("Got no error for a non-array input")
+	}
+	if err := other.ReadJSON(strings.NewReader(`[{"key":1,"value":"a"},{"key":`)); err == nil {
+		t.Errorf("Got no error for a truncated input")
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys()), "[1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestBTreeString(t *testing.T) {
 	c := NewWithStringComparator[string, int](3)
 	c.Put("a", 1)
@@ -1221,6 +2204,59 @@ func TestBTreeString(t *testing.T) {
 	}
 }
 
+func TestBTreeStringWithValues(t *testing.T) {
+	tree := NewWithIntComparator[int, string](3)
+	if actualValue, expectedValue := tree.StringWithValues(), "BTree\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+	tree.Put(3, "c")
+	if actualValue, expectedValue := tree.StringWithValues(), "BTree\n    1:a\n2:b\n    3:c\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.String(), "BTree\n    1\n2\n    3\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	multimap := NewWithOptions[int, string](3, utils.IntComparator, Options{AllowDuplicates: true})
+	multimap.Put(1, "a")
+	multimap.Put(1, "b")
+	if actualValue, expectedValue := multimap.StringWithValues(), "BTree\n1:[a b]\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeFormatters(t *testing.T) {
+	type point struct{ x, y int }
+	comparator := func(a, b interface{}) int {
+		p, q := a.(*point), b.(*point)
+		if c := utils.IntComparator(p.x, q.x); c != 0 {
+			return c
+		}
+		return utils.IntComparator(p.y, q.y)
+	}
+	tree := NewWith[*point, string](3, comparator)
+	tree.Put(&point{1, 2}, "a")
+	tree.Put(&point{0, 5}, "b")
+	tree.KeyFormatter = func(key *point) string { return fmt.Sprintf("(%d,%d)", key.x, key.y) }
+	if actualValue, expectedValue := tree.String(), "BTree\n(0,5)\n(1,2)\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.ValueFormatter = strings.ToUpper
+	if actualValue, expectedValue := tree.StringWithValues(), "BTree\n(0,5):B\n(1,2):A\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	multimap := NewWithOptions[int, string](3, utils.IntComparator, Options{AllowDuplicates: true})
+	multimap.Put(1, "a")
+	multimap.Put(1, "b")
+	multimap.ValueFormatter = strconv.Quote
+	if actualValue, expectedValue := multimap.StringWithValues(), "BTree\n1:[\"a\" \"b\"]\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func benchmarkGet(b *testing.B, tree *Tree[int, struct{}], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -1245,6 +2281,31 @@ func benchmarkRemove(b *testing.B, tree *Tree[int, struct{}], size int) {
 	}
 }
 
+func benchmarkIterator(b *testing.B, tree *Tree[int, struct{}]) {
+	for i := 0; i < b.N; i++ {
+		for it := tree.Iterator(); it.Next(); {
+			it.Key()
+		}
+	}
+}
+
+func benchmarkReverseIterator(b *testing.B, tree *Tree[int, struct{}]) {
+	for i := 0; i < b.N; i++ {
+		it := tree.Iterator()
+		for it.End(); it.Prev(); {
+			it.Key()
+		}
+	}
+}
+
+func benchmarkEachInOrder(b *testing.B, tree *Tree[int, struct{}]) {
+	for i := 0; i < b.N; i++ {
+		tree.EachInOrder(func(key int, value struct{}) bool {
+			return true
+		})
+	}
+}
+
 func BenchmarkBTreeGet100(b *testing.B) {
 	b.StopTimer()
 	size := 100
@@ -1373,3 +2434,47 @@ func BenchmarkBTreeRemove100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkRemove(b, tree, size)
 }
+
+func BenchmarkBTreeIterator100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator[int, struct{}](128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkIterator(b, tree)
+}
+
+func BenchmarkBTreeIteratorOrder3_100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator[int, struct{}](3)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkIterator(b, tree)
+}
+
+func BenchmarkBTreeReverseIteratorOrder3_100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator[int, struct{}](3)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkReverseIterator(b, tree)
+}
+
+func BenchmarkBTreeEachInOrder100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator[int, struct{}](128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkEachInOrder(b, tree)
+}
@@ -11,12 +11,29 @@ var _ containers.ReverseIteratorWithKey[int, int] = (*Iterator[int, int])(nil)
 
 // Iterator holding the iterator's state
 type Iterator[TKey, TValue comparable] struct {
-	tree     *Tree[TKey, TValue]
-	node     *Node[TKey, TValue]
-	entry    *Entry[TKey, TValue]
-	position position
+	tree      *Tree[TKey, TValue]
+	node      *Node[TKey, TValue]
+	entry     *Entry[TKey, TValue]
+	path      [maxHeight]ancestor[TKey, TValue] // ancestors of the current node from the root down
+	depth     int                               // number of ancestors of the current node held in path
+	duplicate int                               // index of the current value within the entry's values (non-zero in multimap mode only)
+	index     int                               // in-order position of the current element, -1 before the first and size past the last element
+	unindexed bool                              // whether index is yet to be computed for the current element (after IteratorAt)
+	position  position
 }
 
+// ancestor is a node on the path from the root to the iterator's current node, along with the index of the child
+// the path continues with. The iterator moves up through these rather than through parent links, which are
+// unreliable in shared nodes (see PutPersistent).
+type ancestor[TKey, TValue comparable] struct {
+	node  *Node[TKey, TValue]
+	child int
+}
+
+// maxHeight bounds the height of any tree: as every internal node has at least two children, a tree of height h
+// holds at least 2^(h-1) entries.
+const maxHeight = 64
+
 type position byte
 
 const (
@@ -25,7 +42,29 @@ const (
 
 // Iterator returns a stateful iterator whose elements are key/value pairs.
 func (tree *Tree[TKey, TValue]) Iterator() Iterator[TKey, TValue] {
-	return Iterator[TKey, TValue]{tree: tree, node: nil, position: begin}
+	return Iterator[TKey, TValue]{tree: tree, node: nil, index: -1, position: begin}
+}
+
+// IteratorAt returns a stateful iterator positioned at the element with the given key,
+// so that Next() and Prev() continue the in-order traversal from there.
+// If the key is not found, the iterator is positioned one-past-the-end and second return parameter is false.
+// Positioning takes O(log n), the iterator's Index is only computed when first asked for (see Index).
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) IteratorAt(key TKey) (Iterator[TKey, TValue], bool) {
+	iterator := Iterator[TKey, TValue]{tree: tree, node: tree.Root, unindexed: true, position: between}
+	for iterator.node != nil {
+		index, found := tree.search(iterator.node, key)
+		if found {
+			iterator.entry = iterator.node.Entries[index]
+			return iterator, true
+		}
+		if tree.isLeaf(iterator.node) {
+			break
+		}
+		iterator.descend(index)
+	}
+	iterator.End()
+	return iterator, false
 }
 
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
@@ -33,18 +72,26 @@ func (tree *Tree[TKey, TValue]) Iterator() Iterator[TKey, TValue] {
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
 // Modifies the state of the iterator.
 func (iterator *Iterator[TKey, TValue]) Next() bool {
+	// If the current entry holds further values, move to the next one
+	if iterator.position == between && iterator.duplicate < len(iterator.tree.duplicates[iterator.entry]) {
+		iterator.duplicate++
+		iterator.index++
+		return true
+	}
 	// If already at end, go to end
 	if iterator.position == end {
 		goto end
 	}
 	// If at beginning, get the left-most entry in the tree
 	if iterator.position == begin {
-		left := iterator.tree.Left()
-		if left == nil {
+		if iterator.tree.Empty() {
 			goto end
 		}
-		iterator.node = left
-		iterator.entry = left.Entries[0]
+		iterator.node = iterator.tree.Root
+		for !iterator.tree.isLeaf(iterator.node) {
+			iterator.descend(0)
+		}
+		iterator.entry = iterator.node.Entries[0]
 		goto between
 	}
 	{
@@ -52,10 +99,10 @@ func (iterator *Iterator[TKey, TValue]) Next() bool {
 		e, _ := iterator.tree.search(iterator.node, iterator.entry.Key)
 		// Try to go down to the child right of the current entry
 		if e+1 < len(iterator.node.Children) {
-			iterator.node = iterator.node.Children[e+1]
+			iterator.descend(e + 1)
 			// Try to go down to the child left of the current node
 			for len(iterator.node.Children) > 0 {
-				iterator.node = iterator.node.Children[0]
+				iterator.descend(0)
 			}
 			// Return the left-most entry
 			iterator.entry = iterator.node.Entries[0]
@@ -67,14 +114,12 @@ func (iterator *Iterator[TKey, TValue]) Next() bool {
 			goto between
 		}
 	}
-	// Reached leaf node and there are no entries to the right of the current entry, so go up to the parent
-	for iterator.node.Parent != nil {
-		iterator.node = iterator.node.Parent
-		// Find next entry position in current node (note: search returns the first equal or bigger than entry)
-		e, _ := iterator.tree.search(iterator.node, iterator.entry.Key)
-		// Check that there is a next entry position in current node
-		if e < len(iterator.node.Entries) {
-			iterator.entry = iterator.node.Entries[e]
+	// Reached leaf node and there are no entries to the right of the current entry, so go up the ancestors
+	// until coming from a child that has an entry to its right
+	for iterator.depth > 0 {
+		child := iterator.ascend()
+		if child < len(iterator.node.Entries) {
+			iterator.entry = iterator.node.Entries[child]
 			goto between
 		}
 	}
@@ -84,6 +129,8 @@ end:
 	return false
 
 between:
+	iterator.duplicate = 0
+	iterator.index++
 	iterator.position = between
 	return true
 }
@@ -92,18 +139,26 @@ between:
 // If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
 // Modifies the state of the iterator.
 func (iterator *Iterator[TKey, TValue]) Prev() bool {
+	// If the current entry holds previous values, move to the previous one
+	if iterator.position == between && iterator.duplicate > 0 {
+		iterator.duplicate--
+		iterator.index--
+		return true
+	}
 	// If already at beginning, go to begin
 	if iterator.position == begin {
 		goto begin
 	}
 	// If at end, get the right-most entry in the tree
 	if iterator.position == end {
-		right := iterator.tree.Right()
-		if right == nil {
+		if iterator.tree.Empty() {
 			goto begin
 		}
-		iterator.node = right
-		iterator.entry = right.Entries[len(right.Entries)-1]
+		iterator.node = iterator.tree.Root
+		for !iterator.tree.isLeaf(iterator.node) {
+			iterator.descend(len(iterator.node.Children) - 1)
+		}
+		iterator.entry = iterator.node.Entries[len(iterator.node.Entries)-1]
 		goto between
 	}
 	{
@@ -111,10 +166,10 @@ func (iterator *Iterator[TKey, TValue]) Prev() bool {
 		e, _ := iterator.tree.search(iterator.node, iterator.entry.Key)
 		// Try to go down to the child left of the current entry
 		if e < len(iterator.node.Children) {
-			iterator.node = iterator.node.Children[e]
+			iterator.descend(e)
 			// Try to go down to the child right of the current node
 			for len(iterator.node.Children) > 0 {
-				iterator.node = iterator.node.Children[len(iterator.node.Children)-1]
+				iterator.descend(len(iterator.node.Children) - 1)
 			}
 			// Return the right-most entry
 			iterator.entry = iterator.node.Entries[len(iterator.node.Entries)-1]
@@ -126,14 +181,12 @@ func (iterator *Iterator[TKey, TValue]) Prev() bool {
 			goto between
 		}
 	}
-	// Reached leaf node and there are no entries to the left of the current entry, so go up to the parent
-	for iterator.node.Parent != nil {
-		iterator.node = iterator.node.Parent
-		// Find previous entry position in current node (note: search returns the first equal or bigger than entry)
-		e, _ := iterator.tree.search(iterator.node, iterator.entry.Key)
-		// Check that there is a previous entry position in current node
-		if e-1 >= 0 {
-			iterator.entry = iterator.node.Entries[e-1]
+	// Reached leaf node and there are no entries to the left of the current entry, so go up the ancestors
+	// until coming from a child that has an entry to its left
+	for iterator.depth > 0 {
+		child := iterator.ascend()
+		if child > 0 {
+			iterator.entry = iterator.node.Entries[child-1]
 			goto between
 		}
 	}
@@ -143,6 +196,8 @@ begin:
 	return false
 
 between:
+	iterator.duplicate = len(iterator.tree.duplicates[iterator.entry])
+	iterator.index--
 	iterator.position = between
 	return true
 }
@@ -150,6 +205,9 @@ between:
 // Value returns the current element's value.
 // Does not modify the state of the iterator.
 func (iterator *Iterator[TKey, TValue]) Value() TValue {
+	if iterator.duplicate > 0 {
+		return iterator.tree.duplicates[iterator.entry][iterator.duplicate-1]
+	}
 	return iterator.entry.Value
 }
 
@@ -159,6 +217,21 @@ func (iterator *Iterator[TKey, TValue]) Key() TKey {
 	return iterator.entry.Key
 }
 
+// Index returns the current element's zero-based position in key order,
+// i.e. -1 before the first element and the size of the tree past the last element.
+// The position is tracked in O(1) by Next and Prev, but for an iterator obtained from IteratorAt that has not
+// reached either end since, it is computed on the first call by counting the preceding elements in O(k + log n).
+// Does not modify the position of the iterator.
+func (iterator *Iterator[TKey, TValue]) Index() int {
+	if iterator.unindexed {
+		key := iterator.entry.Key
+		preceding := iterator.tree.countRange(iterator.tree.Root, iterator.tree.LeftKey(), key) - 1 - len(iterator.tree.duplicates[iterator.entry])
+		iterator.index = preceding + iterator.duplicate
+		iterator.unindexed = false
+	}
+	return iterator.index
+}
+
 // Node returns the current element's node.
 // Does not modify the state of the iterator.
 func (iterator *Iterator[TKey, TValue]) Node() *Node[TKey, TValue] {
@@ -169,6 +242,9 @@ func (iterator *Iterator[TKey, TValue]) Node() *Node[TKey, TValue] {
 // Call Next() to fetch the first element if any.
 func (iterator *Iterator[TKey, TValue]) Begin() {
 	iterator.node = nil
+	iterator.depth = 0
+	iterator.index = -1
+	iterator.unindexed = false
 	iterator.position = begin
 	iterator.entry = nil
 }
@@ -177,6 +253,9 @@ func (iterator *Iterator[TKey, TValue]) Begin() {
 // Call Prev() to fetch the last element if any.
 func (iterator *Iterator[TKey, TValue]) End() {
 	iterator.node = nil
+	iterator.depth = 0
+	iterator.index = iterator.tree.size
+	iterator.unindexed = false
 	iterator.position = end
 	iterator.entry = nil
 }
@@ -224,3 +303,18 @@ func (iterator *Iterator[TKey, TValue]) PrevTo(f func(key TKey, value TValue) bo
 	}
 	return false
 }
+
+// descend moves the iterator down to the given child of the current node, keeping the node as an ancestor.
+func (iterator *Iterator[TKey, TValue]) descend(child int) {
+	iterator.path[iterator.depth] = ancestor[TKey, TValue]{node: iterator.node, child: child}
+	iterator.depth++
+	iterator.node = iterator.node.Children[child]
+}
+
+// ascend moves the iterator up to the parent of the current node and returns the index of the child it came from.
+func (iterator *Iterator[TKey, TValue]) ascend() int {
+	iterator.depth--
+	ancestor := iterator.path[iterator.depth]
+	iterator.node = ancestor.node
+	return ancestor.child
+}
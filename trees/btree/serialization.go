@@ -5,7 +5,10 @@
 package btree
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 
 	"github.com/a234567894/gods/containers"
 	"github.com/a234567894/gods/utils"
@@ -14,30 +17,133 @@ import (
 // Assert Serialization implementation
 var _ containers.JSONSerializer = (*Tree[int, int])(nil)
 var _ containers.JSONDeserializer = (*Tree[int, int])(nil)
+var _ containers.JSONStreamSerializer = (*Tree[int, int])(nil)
+var _ containers.JSONStreamDeserializer = (*Tree[int, int])(nil)
 
-// ToJSON outputs the JSON representation of the tree.
+// jsonEntry is the JSON representation of a single key/value pair of the tree.
+type jsonEntry[TKey, TValue comparable] struct {
+	Key   TKey   `json:"key"`
+	Value TValue `json:"value"`
+}
+
+// ToJSON outputs the JSON representation of the tree, i.e. an array of key/value objects in key order.
+// Unlike a JSON object, the array keeps keys typed and ordered, and holds every value of a key in multimap mode.
 func (tree *Tree[TKey, TValue]) ToJSON() ([]byte, error) {
-	elements := make(map[string]interface{})
+	elements := make([]jsonEntry[TKey, TValue], 0, tree.Size())
 	it := tree.Iterator()
 	for it.Next() {
-		elements[utils.ToString(it.Key())] = it.Value()
+		elements = append(elements, jsonEntry[TKey, TValue]{Key: it.Key(), Value: it.Value()})
 	}
 	return json.Marshal(&elements)
 }
 
 // FromJSON populates the tree from the input JSON representation.
+// The input is either an array of key/value objects as output by ToJSON or a JSON object mapping keys to values.
+// Entries are sorted with the tree's comparator before they are inserted, so the resulting order never depends
+// on the order of the input.
 func (tree *Tree[TKey, TValue]) FromJSON(data []byte) error {
-	elements := make(map[TKey]TValue)
-	err := json.Unmarshal(data, &elements)
-	if err == nil {
-		tree.Clear()
-		for key, value := range elements {
-			tree.Put(key, value)
+	var elements []jsonEntry[TKey, TValue]
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '[' {
+		object := make(map[TKey]TValue)
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		for key, value := range object {
+			elements = append(elements, jsonEntry[TKey, TValue]{Key: key, Value: value})
+		}
+	} else if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	utils.SortStable(elements, func(a, b interface{}) int {
+		return tree.Comparator(a.(jsonEntry[TKey, TValue]).Key, b.(jsonEntry[TKey, TValue]).Key)
+	})
+	tree.Clear()
+	for _, element := range elements {
+		tree.Put(element.Key, element.Value)
+	}
+	return nil
+}
+
+// WriteJSON writes the same JSON representation as ToJSON to the writer, encoding one entry at a time in key order,
+// so that the representation of the whole tree is never held in memory.
+func (tree *Tree[TKey, TValue]) WriteJSON(w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	it := tree.Iterator()
+	for i := 0; it.Next(); i++ {
+		data, err := json.Marshal(jsonEntry[TKey, TValue]{Key: it.Key(), Value: it.Value()})
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
 		}
 	}
+	_, err := io.WriteString(w, "]")
 	return err
 }
 
+// ReadJSON populates the tree from the input JSON representation like FromJSON, i.e. either an array of key/value
+// objects as output by ToJSON or WriteJSON or a JSON object mapping keys to values, but decodes and inserts one entry
+// at a time, so that the whole input is never held in memory. Entries are therefore inserted in input order.
+// The tree is cleared first; if an error occurs, the tree holds the entries decoded up to that point.
+func (tree *Tree[TKey, TValue]) ReadJSON(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('[') && token != json.Delim('{') {
+		return fmt.Errorf("expected an array of key/value objects or an object, got %v", token)
+	}
+	tree.Clear()
+	for decoder.More() {
+		var element jsonEntry[TKey, TValue]
+		if token == json.Delim('{') {
+			element, err = decodeMember[TKey, TValue](decoder)
+		} else {
+			err = decoder.Decode(&element)
+		}
+		if err != nil {
+			return err
+		}
+		tree.Put(element.Key, element.Value)
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// decodeMember decodes the next member of the JSON object being read by the decoder into a key/value pair.
+// The member is decoded as a whole, as the keys of a JSON object follow the rules of encoding/json for map keys.
+func decodeMember[TKey, TValue comparable](decoder *json.Decoder) (element jsonEntry[TKey, TValue], err error) {
+	name, err := decoder.Token()
+	if err != nil {
+		return element, err
+	}
+	var value json.RawMessage
+	if err := decoder.Decode(&value); err != nil {
+		return element, err
+	}
+	member, err := json.Marshal(map[string]json.RawMessage{name.(string): value})
+	if err != nil {
+		return element, err
+	}
+	object := make(map[TKey]TValue, 1)
+	if err := json.Unmarshal(member, &object); err != nil {
+		return element, err
+	}
+	for key, value := range object {
+		element = jsonEntry[TKey, TValue]{Key: key, Value: value}
+	}
+	return element, nil
+}
+
 // UnmarshalJSON @implements json.Unmarshaler
 func (tree *Tree[TKey, TValue]) UnmarshalJSON(bytes []byte) error {
 	return tree.FromJSON(bytes)
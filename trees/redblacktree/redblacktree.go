@@ -13,6 +13,7 @@ package redblacktree
 
 import (
 	"fmt"
+	"math/bits"
 
 	"github.com/a234567894/gods/trees"
 	"github.com/a234567894/gods/utils"
@@ -39,6 +40,7 @@ type Node[TKey, TValue comparable] struct {
 	Key    TKey
 	Value  TValue
 	color  color
+	count  int // number of nodes in the subtree rooted at this node
 	Left   *Node[TKey, TValue]
 	Right  *Node[TKey, TValue]
 	Parent *Node[TKey, TValue]
@@ -66,7 +68,7 @@ func (tree *Tree[TKey, TValue]) Put(key TKey, value TValue) {
 	if tree.Root == nil {
 		// Assert key is of comparator's type for initial tree
 		tree.Comparator(key, key)
-		tree.Root = &Node[TKey, TValue]{Key: key, Value: value, color: red}
+		tree.Root = &Node[TKey, TValue]{Key: key, Value: value, color: red, count: 1}
 		insertedNode = tree.Root
 	} else {
 		node := tree.Root
@@ -80,7 +82,7 @@ func (tree *Tree[TKey, TValue]) Put(key TKey, value TValue) {
 				return
 			case compare < 0:
 				if node.Left == nil {
-					node.Left = &Node[TKey, TValue]{Key: key, Value: value, color: red}
+					node.Left = &Node[TKey, TValue]{Key: key, Value: value, color: red, count: 1}
 					insertedNode = node.Left
 					loop = false
 				} else {
@@ -88,7 +90,7 @@ func (tree *Tree[TKey, TValue]) Put(key TKey, value TValue) {
 				}
 			case compare > 0:
 				if node.Right == nil {
-					node.Right = &Node[TKey, TValue]{Key: key, Value: value, color: red}
+					node.Right = &Node[TKey, TValue]{Key: key, Value: value, color: red, count: 1}
 					insertedNode = node.Right
 					loop = false
 				} else {
@@ -97,11 +99,29 @@ func (tree *Tree[TKey, TValue]) Put(key TKey, value TValue) {
 			}
 		}
 		insertedNode.Parent = node
+		for ; node != nil; node = node.Parent {
+			node.count++
+		}
 	}
 	tree.insertCase1(insertedNode)
 	tree.size++
 }
 
+// FromSorted replaces all nodes of the tree by the given keys and values, building a balanced tree bottom-up in O(n).
+// Keys must be in strictly ascending order with respect to the comparator and values must be of the same length,
+// otherwise the resulting tree is invalid (see Validate).
+func (tree *Tree[TKey, TValue]) FromSorted(keys []TKey, values []TValue) {
+	tree.Clear()
+	if len(keys) == 0 {
+		return
+	}
+	// all leaves end up on the two deepest levels, coloring the deepest level red balances black heights
+	height := bits.Len(uint(len(keys)))
+	tree.Root = buildSorted(keys, values, 0, len(keys), 1, height, nil)
+	tree.Root.color = black
+	tree.size = len(keys)
+}
+
 // Get searches the node in the tree by key and returns its value or nil if key is not found in tree.
 // Second return parameter is true if key was found, otherwise false.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
@@ -147,6 +167,9 @@ func (tree *Tree[TKey, TValue]) Remove(key TKey) {
 		if node.Parent == nil && child != nil {
 			child.color = black
 		}
+		for parent := node.Parent; parent != nil; parent = parent.Parent {
+			parent.count = 1 + parent.Left.Size() + parent.Right.Size()
+		}
 	}
 	tree.size--
 }
@@ -162,19 +185,53 @@ func (tree *Tree[TKey, TValue]) Size() int {
 }
 
 // Size returns the number of elements stored in the subtree.
-// Computed dynamically on each call, i.e. the subtree is traversed to count the number of the nodes.
+// The size is maintained on every modification of the tree, so it is returned in O(1).
 func (node *Node[TKey, TValue]) Size() int {
 	if node == nil {
 		return 0
 	}
-	size := 1
-	if node.Left != nil {
-		size += node.Left.Size()
+	return node.count
+}
+
+// Select returns the node with the given zero-based index in key order, i.e. the node with exactly index smaller keys,
+// in O(log n). Second return parameter is false if the index is out of range.
+func (tree *Tree[TKey, TValue]) Select(index int) (*Node[TKey, TValue], bool) {
+	if index < 0 || index >= tree.size {
+		return nil, false
 	}
-	if node.Right != nil {
-		size += node.Right.Size()
+	node := tree.Root
+	for {
+		left := node.Left.Size()
+		switch {
+		case index < left:
+			node = node.Left
+		case index > left:
+			index -= left + 1
+			node = node.Right
+		default:
+			return node, true
+		}
 	}
-	return size
+}
+
+// Rank returns the number of keys in the tree that are smaller than the given key in O(log n),
+// i.e. the zero-based index of the key in key order if the key is in the tree.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) Rank(key TKey) int {
+	rank := 0
+	for node := tree.Root; node != nil; {
+		compare := tree.Comparator(key, node.Key)
+		switch {
+		case compare < 0:
+			node = node.Left
+		case compare > 0:
+			rank += node.Left.Size() + 1
+			node = node.Right
+		default:
+			return rank + node.Left.Size()
+		}
+	}
+	return rank
 }
 
 // Keys returns all keys in-order
@@ -277,6 +334,64 @@ func (tree *Tree[TKey, TValue]) Ceiling(key TKey) (ceiling *Node[TKey, TValue],
 	return nil, false
 }
 
+// Higher finds the smallest node whose key is strictly larger than the input key, or nil if there is none.
+// Second return parameter is true if such a node was found, otherwise false.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) Higher(key TKey) (higher *Node[TKey, TValue], found bool) {
+	for node := tree.Root; node != nil; {
+		if tree.Comparator(key, node.Key) < 0 {
+			higher, found = node, true
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return higher, found
+}
+
+// Lower finds the largest node whose key is strictly smaller than the input key, or nil if there is none.
+// Second return parameter is true if such a node was found, otherwise false.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree[TKey, TValue]) Lower(key TKey) (lower *Node[TKey, TValue], found bool) {
+	for node := tree.Root; node != nil; {
+		if tree.Comparator(key, node.Key) > 0 {
+			lower, found = node, true
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	return lower, found
+}
+
+// Validate checks the red-black tree invariants by a full traversal, i.e. that keys are ordered with respect to
+// the comparator, parent links are consistent, the root is black, no red node has a red child,
+// every path from a node to its leaves contains the same number of black nodes and the sizes are accurate.
+// Returns an error describing the first violation, otherwise nil.
+func (tree *Tree[TKey, TValue]) Validate() error {
+	if tree.Root == nil {
+		if tree.size != 0 {
+			return fmt.Errorf("empty tree has size %d", tree.size)
+		}
+		return nil
+	}
+	if tree.Root.Parent != nil {
+		return fmt.Errorf("root %v has a parent", tree.Root)
+	}
+	if tree.Root.color != black {
+		return fmt.Errorf("root %v is red", tree.Root)
+	}
+	if _, err := tree.validate(tree.Root, nil, nil); err != nil {
+		return err
+	}
+	if size := tree.Root.Size(); size != tree.size {
+		return fmt.Errorf("tree has %d nodes, size is %d", size, tree.size)
+	}
+	return nil
+}
+
 // Clear removes all nodes from the tree.
 func (tree *Tree[TKey, TValue]) Clear() {
 	tree.Root = nil
@@ -296,6 +411,21 @@ func (node *Node[TKey, TValue]) String() string {
 	return fmt.Sprintf("%v", node.Key)
 }
 
+// buildSorted builds a balanced subtree from keys[low:high] at the given depth (root has depth 1)
+func buildSorted[TKey, TValue comparable](keys []TKey, values []TValue, low, high, depth, height int, parent *Node[TKey, TValue]) *Node[TKey, TValue] {
+	if low >= high {
+		return nil
+	}
+	middle := low + (high-low)/2
+	node := &Node[TKey, TValue]{Key: keys[middle], Value: values[middle], color: black, count: high - low, Parent: parent}
+	if depth == height {
+		node.color = red
+	}
+	node.Left = buildSorted(keys, values, low, middle, depth+1, height, node)
+	node.Right = buildSorted(keys, values, middle+1, high, depth+1, height, node)
+	return node
+}
+
 func output[TKey, TValue comparable](node *Node[TKey, TValue], prefix string, isTail bool, str *string) {
 	if node.Right != nil {
 		newPrefix := prefix
@@ -324,6 +454,49 @@ func output[TKey, TValue comparable](node *Node[TKey, TValue], prefix string, is
 	}
 }
 
+// validate checks the subtree rooted at node whose keys must lie strictly between the optional lower and upper bounds
+// and returns its black height.
+func (tree *Tree[TKey, TValue]) validate(node *Node[TKey, TValue], lower, upper *Node[TKey, TValue]) (int, error) {
+	if node == nil {
+		return 1, nil
+	}
+	if lower != nil && tree.Comparator(node.Key, lower.Key) <= 0 {
+		return 0, fmt.Errorf("node %v is not greater than %v", node, lower)
+	}
+	if upper != nil && tree.Comparator(node.Key, upper.Key) >= 0 {
+		return 0, fmt.Errorf("node %v is not less than %v", node, upper)
+	}
+	for _, child := range []*Node[TKey, TValue]{node.Left, node.Right} {
+		if child == nil {
+			continue
+		}
+		if child.Parent != node {
+			return 0, fmt.Errorf("node %v has an inconsistent parent link", child)
+		}
+		if node.color == red && child.color == red {
+			return 0, fmt.Errorf("red node %v has a red child %v", node, child)
+		}
+	}
+	left, err := tree.validate(node.Left, lower, node)
+	if err != nil {
+		return 0, err
+	}
+	right, err := tree.validate(node.Right, node, upper)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, fmt.Errorf("node %v has black heights %d and %d", node, left, right)
+	}
+	if count := 1 + node.Left.Size() + node.Right.Size(); node.count != count {
+		return 0, fmt.Errorf("node %v has %d nodes in its subtree, count is %d", node, count, node.count)
+	}
+	if node.color == black {
+		left++
+	}
+	return left, nil
+}
+
 func (tree *Tree[TKey, TValue]) lookup(key TKey) *Node[TKey, TValue] {
 	node := tree.Root
 	for node != nil {
@@ -373,6 +546,8 @@ func (tree *Tree[TKey, TValue]) rotateLeft(node *Node[TKey, TValue]) {
 	}
 	right.Left = node
 	node.Parent = right
+	right.count = node.count
+	node.count = 1 + node.Left.Size() + node.Right.Size()
 }
 
 func (tree *Tree[TKey, TValue]) rotateRight(node *Node[TKey, TValue]) {
@@ -384,6 +559,8 @@ func (tree *Tree[TKey, TValue]) rotateRight(node *Node[TKey, TValue]) {
 	}
 	left.Right = node
 	node.Parent = left
+	left.count = node.count
+	node.count = 1 + node.Left.Size() + node.Right.Size()
 }
 
 func (tree *Tree[TKey, TValue]) replaceNode(old *Node[TKey, TValue], new *Node[TKey, TValue]) {
@@ -5,8 +5,10 @@
 package redblacktree
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"strings"
 	"testing"
 
@@ -234,6 +236,168 @@ func TestRedBlackTreeCeilingAndFloor(t *testing.T) {
 	}
 }
 
+func TestRedBlackTreeValidate(t *testing.T) {
+	tree := NewWithIntComparator[int, int]()
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		tree.Put((i*37)%100, i)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	for i := 0; i < 100; i += 3 {
+		tree.Remove(i)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	tree.Left().Key = 1000 // out of order
+	if err := tree.Validate(); err == nil {
+		t.Errorf("Expected error on unordered keys")
+	}
+	tree.Left().Key = -1
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	tree.Root.color = red
+	if err := tree.Validate(); err == nil {
+		t.Errorf("Expected error on red root")
+	}
+	tree.Root.color = black
+
+	tree.Root.Left.color = !tree.Root.Left.color // unbalanced black height
+	if err := tree.Validate(); err == nil {
+		t.Errorf("Expected error on unbalanced black height")
+	}
+	tree.Root.Left.color = !tree.Root.Left.color
+
+	tree.size++
+	if err := tree.Validate(); err == nil {
+		t.Errorf("Expected error on invalid size")
+	}
+}
+
+func TestRedBlackTreeHigherAndLower(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+	if node, found := tree.Higher(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	tree.Put(1, "a")
+	tree.Put(3, "c")
+	tree.Put(5, "e")
+	tests := [][]interface{}{
+		{0, 1, nil},
+		{1, 3, nil},
+		{2, 3, 1},
+		{3, 5, 1},
+		{5, nil, 3},
+		{6, nil, 5},
+	}
+	for _, test := range tests {
+		node, found := tree.Higher(test[0].(int))
+		if (test[1] == nil && (node != nil || found)) || (test[1] != nil && (!found || node.Key != test[1])) {
+			t.Errorf("Got %v expected %v", node, test[1])
+		}
+		node, found = tree.Lower(test[0].(int))
+		if (test[2] == nil && (node != nil || found)) || (test[2] != nil && (!found || node.Key != test[2])) {
+			t.Errorf("Got %v expected %v", node, test[2])
+		}
+	}
+}
+
+func TestRedBlackTreeSelectAndRank(t *testing.T) {
+	tree := NewWithIntComparator[int, int]()
+	if node, found := tree.Select(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, nil)
+	}
+	if actualValue := tree.Rank(5); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		key := random.Intn(200)
+		if random.Intn(3) == 0 {
+			tree.Remove(key)
+		} else {
+			tree.Put(key, key)
+		}
+		if i%50 != 0 {
+			continue
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+			return
+		}
+		keys := tree.Keys()
+		for index, key := range keys {
+			if node, found := tree.Select(index); !found || node.Key != key {
+				t.Errorf("Got %v expected %v", node, key)
+			}
+			if actualValue := tree.Rank(key); actualValue != index {
+				t.Errorf("Got %v expected %v", actualValue, index)
+			}
+		}
+		if node, found := tree.Select(len(keys)); node != nil || found {
+			t.Errorf("Got %v expected %v", node, nil)
+		}
+		if node, found := tree.Select(-1); node != nil || found {
+			t.Errorf("Got %v expected %v", node, nil)
+		}
+	}
+
+	tree.FromSorted([]int{1, 3, 5, 7, 9}, []int{1, 3, 5, 7, 9})
+	if actualValue, expectedValue := fmt.Sprint(tree.Rank(0), tree.Rank(4), tree.Rank(9), tree.Rank(10)), "0 2 4 5"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if node, _ := tree.Select(2); node.Key != 5 {
+		t.Errorf("Got %v expected %v", node.Key, 5)
+	}
+}
+
+func TestRedBlackTreeFromSorted(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+	tree.Put(100, "x")
+	tree.FromSorted([]int{}, []string{})
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	for n := 1; n <= 64; n++ {
+		keys := make([]int, n)
+		values := make([]string, n)
+		for i := range keys {
+			keys[i] = i * 2
+			values[i] = fmt.Sprintf("%d", i*2)
+		}
+		tree.FromSorted(keys, values)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v for size %d", err, n)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(keys); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, found := tree.Get(keys[n-1]); actualValue != values[n-1] || !found {
+			t.Errorf("Got %v expected %v", actualValue, values[n-1])
+		}
+	}
+
+	// tree remains fully functional
+	tree.Put(1, "1")
+	tree.Remove(0)
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue := tree.Size(); actualValue != 64 {
+		t.Errorf("Got %v expected %v", actualValue, 64)
+	}
+}
+
 func TestRedBlackTreeIteratorNextOnEmpty(t *testing.T) {
 	tree := NewWithIntComparator[int, string]()
 	it := tree.Iterator()
@@ -698,6 +862,52 @@ func TestRedBlackTreeSerialization(t *testing.T) {
 	}
 }
 
+func TestRedBlackTreeStreamJSON(t *testing.T) {
+	tree := NewWithIntComparator[int, string]()
+	for i := 20; i > 0; i-- {
+		tree.Put(i, fmt.Sprint("v", i))
+	}
+	var buf bytes.Buffer
+	if err := tree.WriteJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	// the members are written in key order rather than in the order of their JSON strings
+	if actualValue, expectedValue := buf.String(), `{"1":"v1","2":"v2","3":"v3",`; !strings.HasPrefix(actualValue, expectedValue) {
+		t.Errorf("Got %v expected prefix %v", actualValue, expectedValue)
+	}
+	if !json.Valid(buf.Bytes()) {
+		t.Errorf("Got invalid JSON %v", buf.String())
+	}
+
+	other := NewWithIntComparator[int, string]()
+	other.Put(100, "stale")
+	if err := other.ReadJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys(), other.Values()), fmt.Sprint(tree.Keys(), tree.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	empty := NewWithIntComparator[int, string]()
+	buf.Reset()
+	if err := empty.WriteJSON(&buf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := buf.String(), "{}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if err := other.ReadJSON(strings.NewReader(`"a"`)); err == nil {
+		t.Errorf("Got no error for a non-object input")
+	}
+	if err := other.ReadJSON(strings.NewReader(`{"1":"a","2":`)); err == nil {
+		t.Errorf("Got no error for a truncated input")
+	}
+	if actualValue, expectedValue := fmt.Sprint(other.Keys()), "[1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestRedBlackTreeString(t *testing.T) {
 	c := NewWithStringComparator[string, int]()
 	c.Put("a", 1)
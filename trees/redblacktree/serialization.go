@@ -6,6 +6,8 @@ package redblacktree
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 
 	"github.com/a234567894/gods/containers"
 )
@@ -13,6 +15,8 @@ import (
 // Assert Serialization implementation
 var _ containers.JSONSerializer = (*Tree[int, int])(nil)
 var _ containers.JSONDeserializer = (*Tree[int, int])(nil)
+var _ containers.JSONStreamSerializer = (*Tree[int, int])(nil)
+var _ containers.JSONStreamDeserializer = (*Tree[int, int])(nil)
 
 // ToJSON outputs the JSON representation of the tree.
 func (tree *Tree[TKey, TValue]) ToJSON() ([]byte, error) {
@@ -37,6 +41,81 @@ func (tree *Tree[TKey, TValue]) FromJSON(data []byte) error {
 	return err
 }
 
+// WriteJSON writes a JSON object mapping keys to values to the writer like ToJSON, encoding one member at a time
+// in key order, so that the representation of the whole tree is never held in memory.
+func (tree *Tree[TKey, TValue]) WriteJSON(w io.Writer) error {
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+	it := tree.Iterator()
+	for i := 0; it.Next(); i++ {
+		// Encode the member as a whole, as the keys of a JSON object follow the rules of encoding/json for map keys
+		data, err := json.Marshal(map[TKey]TValue{it.Key(): it.Value()})
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data[1 : len(data)-1]); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "}")
+	return err
+}
+
+// ReadJSON populates the tree from a JSON object mapping keys to values as output by ToJSON or WriteJSON,
+// decoding and inserting one member at a time in input order, so that the whole input is never held in memory.
+// The tree is cleared first; if an error occurs, the tree holds the entries decoded up to that point.
+func (tree *Tree[TKey, TValue]) ReadJSON(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return fmt.Errorf("expected an object, got %v", token)
+	}
+	tree.Clear()
+	for decoder.More() {
+		key, value, err := decodeMember[TKey, TValue](decoder)
+		if err != nil {
+			return err
+		}
+		tree.Put(key, value)
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// decodeMember decodes the next member of the JSON object being read by the decoder into a key/value pair.
+// The member is decoded as a whole, as the keys of a JSON object follow the rules of encoding/json for map keys.
+func decodeMember[TKey, TValue comparable](decoder *json.Decoder) (key TKey, value TValue, err error) {
+	name, err := decoder.Token()
+	if err != nil {
+		return key, value, err
+	}
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return key, value, err
+	}
+	member, err := json.Marshal(map[string]json.RawMessage{name.(string): raw})
+	if err != nil {
+		return key, value, err
+	}
+	object := make(map[TKey]TValue, 1)
+	if err := json.Unmarshal(member, &object); err != nil {
+		return key, value, err
+	}
+	for decodedKey, decodedValue := range object {
+		return decodedKey, decodedValue, nil
+	}
+	return key, value, nil
+}
+
 // UnmarshalJSON @implements json.Unmarshaler
 func (tree *Tree[TKey, TValue]) UnmarshalJSON(bytes []byte) error {
 	return tree.FromJSON(bytes)
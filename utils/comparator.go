@@ -4,7 +4,10 @@
 
 package utils
 
-import "time"
+import (
+	"bytes"
+	"time"
+)
 
 // Comparator will make type assertion (see IntComparator for example),
 // which will panic if a or b are not of the asserted type.
@@ -15,6 +18,52 @@ import "time"
 //    positive , if a > b
 type Comparator func(a, b interface{}) int
 
+// Ordered is a constraint that permits any type that supports the operators < <= >= >.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// OrderedComparator returns a comparator for any ordered type T using its natural order,
+// e.g. OrderedComparator[int]() behaves like IntComparator.
+// Elements should be of type T, otherwise the returned comparator panics.
+func OrderedComparator[T Ordered]() Comparator {
+	return func(a, b interface{}) int {
+		aAsserted := a.(T)
+		bAsserted := b.(T)
+		switch {
+		case aAsserted > bAsserted:
+			return 1
+		case aAsserted < bAsserted:
+			return -1
+		default:
+			return 0
+		}
+	}
+}
+
+// Reverse returns a comparator that orders elements in the opposite order of the given comparator.
+func Reverse(comparator Comparator) Comparator {
+	return func(a, b interface{}) int {
+		return -comparator(a, b)
+	}
+}
+
+// Chain returns a comparator that applies the given comparators from left to right
+// and returns the first non-zero result, or zero if all comparators consider a and b equal.
+func Chain(comparators ...Comparator) Comparator {
+	return func(a, b interface{}) int {
+		for _, comparator := range comparators {
+			if result := comparator(a, b); result != 0 {
+				return result
+			}
+		}
+		return 0
+	}
+}
+
 // StringComparator provides a fast comparison on strings
 func StringComparator(a, b interface{}) int {
 	s1 := a.(string)
@@ -236,6 +285,8 @@ func RuneComparator(a, b interface{}) int {
 }
 
 // TimeComparator provides a basic comparison on time.Time
+//
+// The zero time sorts before any other time (after any time before year 1).
 func TimeComparator(a, b interface{}) int {
 	aAsserted := a.(time.Time)
 	bAsserted := b.(time.Time)
@@ -249,3 +300,10 @@ func TimeComparator(a, b interface{}) int {
 		return 0
 	}
 }
+
+// ByteSliceComparator provides a lexicographic comparison on []byte (see bytes.Compare)
+//
+// A nil slice is equivalent to an empty slice and sorts before any non-empty slice.
+func ByteSliceComparator(a, b interface{}) int {
+	return bytes.Compare(a.([]byte), b.([]byte))
+}
@@ -63,6 +63,8 @@ func TestTimeComparator(t *testing.T) {
 		{now, now, 0},
 		{now.Add(24 * 7 * 2 * time.Hour), now, 1},
 		{now, now.Add(24 * 7 * 2 * time.Hour), -1},
+		{time.Time{}, now, -1},
+		{time.Time{}, time.Time{}, 0},
 	}
 
 	for _, test := range tests {
@@ -111,6 +113,83 @@ func TestCustomComparator(t *testing.T) {
 	}
 }
 
+func TestOrderedComparator(t *testing.T) {
+	type Celsius float64
+
+	// c,o1,o2,expected
+	tests := [][]interface{}{
+		{OrderedComparator[int](), 1, 1, 0},
+		{OrderedComparator[int](), 1, 2, -1},
+		{OrderedComparator[int](), 2, 1, 1},
+		{OrderedComparator[string](), "aa", "aab", -1},
+		{OrderedComparator[string](), "b", "a", 1},
+		{OrderedComparator[uint8](), uint8(3), uint8(3), 0},
+		{OrderedComparator[Celsius](), Celsius(-1.5), Celsius(2), -1},
+	}
+
+	for _, test := range tests {
+		actual := test[0].(Comparator)(test[1], test[2])
+		expected := test[3]
+		if actual != expected {
+			t.Errorf("Got %v expected %v", actual, expected)
+		}
+	}
+}
+
+func TestReverseComparator(t *testing.T) {
+	// i1,i2,expected
+	tests := [][]interface{}{
+		{1, 1, 0},
+		{1, 2, 1},
+		{2, 1, -1},
+	}
+
+	reversed := Reverse(IntComparator)
+	for _, test := range tests {
+		actual := reversed(test[0], test[1])
+		expected := test[2]
+		if actual != expected {
+			t.Errorf("Got %v expected %v", actual, expected)
+		}
+	}
+}
+
+func TestChainComparator(t *testing.T) {
+	type Person struct {
+		lastName  string
+		firstName string
+	}
+
+	byLastName := func(a, b interface{}) int {
+		return StringComparator(a.(Person).lastName, b.(Person).lastName)
+	}
+	byFirstName := func(a, b interface{}) int {
+		return StringComparator(a.(Person).firstName, b.(Person).firstName)
+	}
+
+	// p1,p2,expected
+	tests := [][]interface{}{
+		{Person{"doe", "john"}, Person{"doe", "john"}, 0},
+		{Person{"doe", "jane"}, Person{"doe", "john"}, -1},
+		{Person{"doe", "john"}, Person{"doe", "jane"}, 1},
+		{Person{"adams", "zoe"}, Person{"doe", "anna"}, -1},
+		{Person{"smith", "anna"}, Person{"doe", "zoe"}, 1},
+	}
+
+	chained := Chain(byLastName, byFirstName)
+	for _, test := range tests {
+		actual := chained(test[0], test[1])
+		expected := test[2]
+		if actual != expected {
+			t.Errorf("Got %v expected %v", actual, expected)
+		}
+	}
+
+	if actual := Chain()(1, 2); actual != 0 {
+		t.Errorf("Got %v expected %v", actual, 0)
+	}
+}
+
 func TestInt8ComparatorComparator(t *testing.T) {
 	tests := [][]interface{}{
 		{int8(1), int8(1), 0},
@@ -305,3 +384,22 @@ func TestRuneComparator(t *testing.T) {
 		}
 	}
 }
+
+func TestByteSliceComparator(t *testing.T) {
+	tests := [][]interface{}{
+		{[]byte("a"), []byte("a"), 0},
+		{[]byte("a"), []byte("b"), -1},
+		{[]byte("b"), []byte("a"), 1},
+		{[]byte("aa"), []byte("aab"), -1},
+		{[]byte{}, []byte(nil), 0},
+		{[]byte(nil), []byte("a"), -1},
+		{[]byte{0xff}, []byte{0x00, 0xff}, 1},
+	}
+	for _, test := range tests {
+		actual := ByteSliceComparator(test[0], test[1])
+		expected := test[2]
+		if actual != expected {
+			t.Errorf("Got %v expected %v", actual, expected)
+		}
+	}
+}
@@ -13,6 +13,12 @@ func Sort[T any](values []T, comparator Comparator) {
 	sort.Sort(sortable[T]{values, comparator})
 }
 
+// SortStable sorts values (in-place) with respect to the given comparator,
+// keeping the original order of elements the comparator considers equal.
+func SortStable[T any](values []T, comparator Comparator) {
+	sort.Stable(sortable[T]{values, comparator})
+}
+
 type sortable[T any] struct {
 	values     []T
 	comparator Comparator
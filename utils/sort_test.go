@@ -79,6 +79,36 @@ func TestSortStructs(t *testing.T) {
 	}
 }
 
+func TestSortStable(t *testing.T) {
+	type User struct {
+		id   int
+		name string
+	}
+
+	byID := func(a, b interface{}) int {
+		return IntComparator(a.(User).id, b.(User).id)
+	}
+
+	users := []User{
+		{2, "a"},
+		{1, "b"},
+		{2, "c"},
+		{1, "d"},
+		{2, "e"},
+		{1, "f"},
+	}
+
+	SortStable(users, byID)
+
+	names := ""
+	for _, user := range users {
+		names += user.name
+	}
+	if actualValue, expectedValue := names, "bdface"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestSortRandom(t *testing.T) {
 	ints := []interface{}{}
 	for i := 0; i < 10000; i++ {
@@ -11,14 +11,21 @@ package utils
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 )
 
 // ToString converts a value to string.
+// Byte slices are converted to the string they hold, types implementing fmt.Stringer are converted by their String
+// method and floats are formatted without exponent unless their magnitude is 1e21 or more.
 func ToString(value interface{}) string {
 	switch value := value.(type) {
 	case string:
 		return value
+	case []byte:
+		return string(value)
+	case int:
+		return strconv.Itoa(value)
 	case int8:
 		return strconv.FormatInt(int64(value), 10)
 	case int16:
@@ -27,6 +34,8 @@ func ToString(value interface{}) string {
 		return strconv.FormatInt(int64(value), 10)
 	case int64:
 		return strconv.FormatInt(value, 10)
+	case uint:
+		return strconv.FormatUint(uint64(value), 10)
 	case uint8:
 		return strconv.FormatUint(uint64(value), 10)
 	case uint16:
@@ -36,12 +45,23 @@ func ToString(value interface{}) string {
 	case uint64:
 		return strconv.FormatUint(value, 10)
 	case float32:
-		return strconv.FormatFloat(float64(value), 'g', -1, 64)
+		return formatFloat(float64(value), 32)
 	case float64:
-		return strconv.FormatFloat(value, 'g', -1, 64)
+		return formatFloat(value, 64)
 	case bool:
 		return strconv.FormatBool(value)
+	case fmt.Stringer:
+		return value.String()
 	default:
 		return fmt.Sprintf("%+v", value)
 	}
 }
+
+// formatFloat formats the float in the shortest decimal notation that round-trips at the given bit size,
+// falling back to the exponent notation for very large magnitudes.
+func formatFloat(value float64, bitSize int) string {
+	if math.Abs(value) >= 1e21 {
+		return strconv.FormatFloat(value, 'g', -1, bitSize)
+	}
+	return strconv.FormatFloat(value, 'f', -1, bitSize)
+}
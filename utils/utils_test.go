@@ -7,6 +7,7 @@ package utils
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestToStringInts(t *testing.T) {
@@ -102,3 +103,33 @@ func TestToStringOther(t *testing.T) {
 		t.Errorf("Got %v expected %v", actualValue, expectedValue)
 	}
 }
+
+type celsius float64
+
+func (c celsius) String() string {
+	return ToString(float64(c)) + "°C"
+}
+
+func TestToStringTypes(t *testing.T) {
+	tests := [][]interface{}{
+		{"abc", "abc"},
+		{[]byte("abc"), "abc"},
+		{int(-1), "-1"},
+		{uint(1), "1"},
+		{float32(0.1), "0.1"},
+		{float64(0.1), "0.1"},
+		{float64(1e20), "100000000000000000000"},
+		{float64(1e21), "1e+21"},
+		{float64(-0.000001), "-0.000001"},
+		{float64(3), "3"},
+		{true, "true"},
+		{celsius(21.5), "21.5°C"},
+		{time.Second, "1s"},
+		{struct{ A int }{1}, "{A:1}"},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := ToString(test[0]), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}